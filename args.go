@@ -0,0 +1,148 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidArgs Tf / KV 的参数不合法，可用 errors.Is 判断
+var ErrInvalidArgs = errors.New("i18n: invalid arguments")
+
+// rootArgKey 单独传入的结构体放在这个 key 下，占位符路径直接从结构体开始解析
+const rootArgKey = "\x00root"
+
+// KV 把 Tf 风格的参数转为 T 使用的 map：
+//
+//	KV("user.name", u.Name, "count", 3) // map[string]any{"user": map[string]any{"name": ...}, "count": 3}
+//
+// 名称中的点表示嵌套。只传一个参数时，它可以直接是 map（key 为字符串）或结构体（及其指针），
+// 此时占位符路径从该值开始解析，例如传入 User 后使用 {name}。
+func KV(kv ...any) (map[string]any, error) {
+	switch len(kv) {
+	case 0:
+		return nil, nil
+	case 1:
+		return singleArg(kv[0])
+	}
+	if len(kv)%2 != 0 {
+		return nil, fmt.Errorf("%w: odd number of key/value arguments (%d)", ErrInvalidArgs, len(kv))
+	}
+
+	args := make(map[string]any, len(kv)/2)
+	created := make(map[string]bool) // setArg 创建的中间 map，按点分隔的前缀记录
+	for i := 0; i < len(kv); i += 2 {
+		name, ok := kv[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: argument %d: name must be a string, got %T", ErrInvalidArgs, i, kv[i])
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%w: argument %d: empty name", ErrInvalidArgs, i)
+		}
+		if err := setArg(args, created, name, kv[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// setArg 按点分隔的名称把 value 放入嵌套的 map。
+// 只会写入自己创建的中间 map（记录在 created 中），调用方作为值传入的 map 不会被修改
+func setArg(args map[string]any, created map[string]bool, name string, value any) error {
+	segs := strings.Split(name, ".")
+	m := args
+	for i, seg := range segs[:len(segs)-1] {
+		prefix := strings.Join(segs[:i+1], ".")
+		switch next := m[seg].(type) {
+		case nil:
+			child := make(map[string]any)
+			m[seg] = child
+			created[prefix] = true
+			m = child
+		case map[string]any:
+			if !created[prefix] {
+				return fmt.Errorf("%w: %q conflicts with %q", ErrInvalidArgs, name, prefix)
+			}
+			m = next
+		default:
+			return fmt.Errorf("%w: %q conflicts with %q", ErrInvalidArgs, name, prefix)
+		}
+	}
+	last := segs[len(segs)-1]
+	if _, exists := m[last]; exists {
+		return fmt.Errorf("%w: duplicate argument %q", ErrInvalidArgs, name)
+	}
+	m[last] = value
+	return nil
+}
+
+// singleArg 处理只传一个参数的情况
+func singleArg(v any) (map[string]any, error) {
+	switch a := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return a, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: map key must be a string, got %s", ErrInvalidArgs, rv.Type().Key())
+		}
+		args := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			args[iter.Key().String()] = iter.Value().Interface()
+		}
+		return args, nil
+	case reflect.Struct:
+		return map[string]any{rootArgKey: v}, nil
+	case reflect.Pointer:
+		if rv.Elem().Kind() == reflect.Struct {
+			return map[string]any{rootArgKey: v}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: a single argument must be a map or struct, got %T", ErrInvalidArgs, v)
+}
+
+// Tf 与 T 相同，但参数以交替的名称/值传入：
+//
+//	loc.Tf("order.paid", "user.name", u.Name, "amount", 12.5)
+//	loc.Tf("user.profile", u) // 单个结构体或 map
+//
+// 参数不合法时按渲染失败处理（返回原文并触发 Config.OnRenderError），需要拿到错误时使用 Translatef
+func (l *Locale) Tf(key string, kv ...any) string {
+	args, err := KV(kv...)
+	if err != nil {
+		text, err := l.invalidArgs(key, err)
+		return l.fail(key, nil, text, err)
+	}
+	return l.T(key, args)
+}
+
+// Translatef 与 Translate 相同，参数格式同 Tf；
+// 参数不合法时返回原文与 *RenderError；找不到翻译时返回 key 与 *MissingKeyError。
+// 两种情况下 errors.Is(err, ErrInvalidArgs) 都为 true
+func (l *Locale) Translatef(key string, kv ...any) (string, error) {
+	args, err := KV(kv...)
+	if err != nil {
+		return l.invalidArgs(key, err)
+	}
+	return l.Translate(key, args)
+}
+
+// invalidArgs 参数不合法时与渲染失败一样返回原文；
+// 找不到翻译或读取 Store 失败时把该错误与 err 合并返回，不丢失 ErrInvalidArgs
+func (l *Locale) invalidArgs(key string, err error) (string, error) {
+	tr, ok, ferr := l.candidates(key).next()
+	if ferr != nil {
+		return key, errors.Join(ferr, err)
+	}
+	if !ok {
+		return key, errors.Join(&MissingKeyError{Key: key, Langs: l.langs}, err)
+	}
+	return tr.text, &RenderError{Key: key, Lang: tr.lang, Err: err}
+}
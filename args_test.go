@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type tfUser struct {
+	Name string
+}
+
+func TestKV(t *testing.T) {
+	got, err := KV("user.name", "Ann", "user.id", 7, "count", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"user": map[string]any{"name": "Ann", "id": 7}, "count": 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("KV = %v", got)
+	}
+
+	if got, err := KV(map[string]string{"name": "Ann"}); err != nil || got["name"] != "Ann" {
+		t.Fatalf("KV(map) = %v, %v", got, err)
+	}
+
+	bad := [][]any{
+		{"name", "Ann", "count"},
+		{1, "Ann"},
+		{"user", "Ann", "user.name", "Bob"},
+		{"name", "Ann", "name", "Bob"},
+		{42},
+		{map[int]string{1: "a"}},
+	}
+	for _, kv := range bad {
+		if _, err := KV(kv...); !errors.Is(err, ErrInvalidArgs) {
+			t.Fatalf("KV(%v): expected ErrInvalidArgs, got %v", kv, err)
+		}
+	}
+
+	// 调用方传入的 map 不会被修改
+	user := map[string]any{"name": "a"}
+	if _, err := KV("user", user, "user.extra", 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Fatalf("expected ErrInvalidArgs, got %v", err)
+	}
+	if len(user) != 1 {
+		t.Fatalf("caller's map was modified: %v", user)
+	}
+}
+
+func TestLocale_Tf(t *testing.T) {
+	var renderErrs []error
+	bundle := New(Config{
+		DefaultLang:   "en",
+		OnRenderError: func(lang, key string, err error) { renderErrs = append(renderErrs, err) },
+	})
+	bundle.RegisterMessages("en", map[string]string{
+		"paid":    "{user.name} paid {amount}",
+		"profile": "Profile of {name}",
+	})
+	loc := bundle.Locale("en")
+
+	if got := loc.Tf("paid", "user.name", "Ann", "amount", 12); got != "Ann paid 12" {
+		t.Fatalf("Tf = %q", got)
+	}
+	if got := loc.Tf("profile", tfUser{Name: "Ann"}); got != "Profile of Ann" {
+		t.Fatalf("Tf(struct) = %q", got)
+	}
+	if got := loc.Tf("profile", &tfUser{Name: "Bob"}); got != "Profile of Bob" {
+		t.Fatalf("Tf(*struct) = %q", got)
+	}
+	if got := loc.Tf("profile", map[string]any{"name": "Cy"}); got != "Profile of Cy" {
+		t.Fatalf("Tf(map) = %q", got)
+	}
+
+	got, err := loc.Translatef("paid", "user.name", "Ann", "amount")
+	if !errors.Is(err, ErrInvalidArgs) || got != "{user.name} paid {amount}" {
+		t.Fatalf("Translatef = %q, %v", got, err)
+	}
+	var renderErr *RenderError
+	if !errors.As(err, &renderErr) || renderErr.Lang != "en" {
+		t.Fatalf("expected RenderError, got %v", err)
+	}
+	if got := loc.Tf("paid", 1, 2); got != "{user.name} paid {amount}" || len(renderErrs) != 1 {
+		t.Fatalf("Tf with bad args = %q, hooks %v", got, renderErrs)
+	}
+
+	// key 不存在时两个错误都保留
+	got, err = loc.Translatef("missing", "name")
+	if got != "missing" || !errors.Is(err, ErrInvalidArgs) || !errors.Is(err, ErrMissingKey) {
+		t.Fatalf("Translatef(missing) = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,335 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// 二进制格式：
+//
+//	magic "I18N" | version uint8 | 字符串表 | 各语言翻译 | 预解析的模板 | crc32 (IEEE, 小端)
+//
+// 所有整数使用 uvarint 编码，字符串在字符串表中只出现一次，其余位置以下标引用。
+// crc32 覆盖它之前的全部字节。
+const (
+	binaryMagic   = "I18N"
+	binaryVersion = 1
+
+	binaryTextNode        = 0
+	binaryPlaceholderNode = 1
+)
+
+// errCorruptBinary 二进制内容被截断或已损坏
+var errCorruptBinary = errors.New("i18n: corrupt binary bundle")
+
+// WriteBinary 把 Bundle 当前的翻译写为紧凑的二进制格式（见 LoadBinary），
+// 每条翻译的模板会预先解析，加载时既不需要解析 YAML 也不需要 ParseTemplate。
+//
+// 只写入翻译本身：元信息、定义位置和覆盖层不会写入；懒加载模式下只写入已经加载的语言。
+func (b *Bundle) WriteBinary(w io.Writer) error {
+	b.wmu.Lock()
+	snapshot, err := copyStore(b.store)
+	b.wmu.Unlock()
+	if err != nil {
+		return err
+	}
+	langs, _ := snapshot.msgs.Languages()
+	catalog := make(map[string][][2]string, len(langs))
+	for _, lang := range langs {
+		keys, _ := snapshot.msgs.Keys(lang)
+		for _, k := range keys {
+			catalog[lang] = append(catalog[lang], [2]string{k, snapshot.msgs[lang][k]})
+		}
+	}
+
+	enc := &binaryEncoder{index: make(map[string]uint64)}
+	templates := make(map[string]TemplateAST)
+	for _, lang := range langs {
+		enc.intern(lang)
+		for _, kv := range catalog[lang] {
+			enc.intern(kv[0])
+			enc.intern(kv[1])
+			collectBinaryTemplate(templates, kv[1])
+		}
+	}
+	tpls := make([]string, 0, len(templates))
+	for tpl := range templates {
+		tpls = append(tpls, tpl)
+	}
+	sort.Strings(tpls)
+	for _, tpl := range tpls {
+		enc.intern(tpl)
+		for _, node := range templates[tpl] {
+			switch n := node.(type) {
+			case *TextNode:
+				enc.intern(n.Text)
+			case *PlaceholderNode:
+				enc.intern(n.Path)
+				for _, f := range n.Formatters {
+					enc.intern(f.Name)
+					enc.intern(f.Arg)
+				}
+				if c := n.Cond; c != nil {
+					enc.intern(c.Op)
+					enc.intern(c.TestValue)
+					enc.intern(c.TrueExpr)
+					enc.intern(c.FalseExpr)
+				}
+			}
+		}
+	}
+
+	buf := append([]byte(binaryMagic), binaryVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(enc.strings)))
+	for _, s := range enc.strings {
+		buf = binary.AppendUvarint(buf, uint64(len(s)))
+		buf = append(buf, s...)
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(langs)))
+	for _, lang := range langs {
+		buf = binary.AppendUvarint(buf, enc.index[lang])
+		buf = binary.AppendUvarint(buf, uint64(len(catalog[lang])))
+		for _, kv := range catalog[lang] {
+			buf = binary.AppendUvarint(buf, enc.index[kv[0]])
+			buf = binary.AppendUvarint(buf, enc.index[kv[1]])
+		}
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(tpls)))
+	for _, tpl := range tpls {
+		ast := templates[tpl]
+		buf = binary.AppendUvarint(buf, enc.index[tpl])
+		buf = binary.AppendUvarint(buf, uint64(len(ast)))
+		for _, node := range ast {
+			switch n := node.(type) {
+			case *TextNode:
+				buf = append(buf, binaryTextNode)
+				buf = binary.AppendUvarint(buf, enc.index[n.Text])
+			case *PlaceholderNode:
+				buf = append(buf, binaryPlaceholderNode)
+				buf = binary.AppendUvarint(buf, enc.index[n.Path])
+				buf = binary.AppendUvarint(buf, uint64(len(n.Formatters)))
+				for _, f := range n.Formatters {
+					buf = binary.AppendUvarint(buf, enc.index[f.Name])
+					buf = binary.AppendUvarint(buf, enc.index[f.Arg])
+				}
+				if c := n.Cond; c != nil {
+					buf = append(buf, 1)
+					buf = binary.AppendUvarint(buf, enc.index[c.Op])
+					buf = binary.AppendUvarint(buf, enc.index[c.TestValue])
+					buf = binary.AppendUvarint(buf, enc.index[c.TrueExpr])
+					buf = binary.AppendUvarint(buf, enc.index[c.FalseExpr])
+				} else {
+					buf = append(buf, 0)
+				}
+			}
+		}
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+	_, err = w.Write(buf)
+	return err
+}
+
+// LoadBinary 读取 WriteBinary 写出的二进制翻译并合并进 Bundle，name 用于错误信息和冲突报告；
+// 每个语言的翻译按一个名为 name 的文件注册，与其他文件的冲突按 Config.ConflictPolicy 处理。
+// 预解析的模板只供本 Bundle（及其 Clone）使用，不会放入全局的模板缓存。
+// 版本不支持或校验和不匹配时返回错误，Bundle 保持不变；校验和只用于发现损坏，不能防止篡改，
+// 因此严格模式（Config.RequireSignature）下 LoadBinary 总是返回 ErrUnsigned。
+func (b *Bundle) LoadBinary(name string, r io.Reader) error {
+	if b.config.RequireSignature {
+		return fmt.Errorf("load %s: %w", name, ErrUnsigned)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(data) < len(binaryMagic)+1+4 || !bytes.HasPrefix(data, []byte(binaryMagic)) {
+		return fmt.Errorf("load %s: i18n: not a binary bundle", name)
+	}
+	if v := data[len(binaryMagic)]; v != binaryVersion {
+		return fmt.Errorf("load %s: i18n: unsupported binary bundle version %d", name, v)
+	}
+	body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return fmt.Errorf("load %s: i18n: binary bundle checksum mismatch", name)
+	}
+
+	dec := &binaryDecoder{data: body[len(binaryMagic)+1:]}
+	n := dec.uvarint()
+	if n > uint64(len(dec.data)) {
+		return fmt.Errorf("load %s: %w", name, errCorruptBinary)
+	}
+	dec.strings = make([]string, n)
+	for i := range dec.strings {
+		size := dec.uvarint()
+		if dec.err != nil || size > uint64(len(dec.data)) {
+			return fmt.Errorf("load %s: %w", name, errCorruptBinary)
+		}
+		dec.strings[i] = string(dec.data[:size])
+		dec.data = dec.data[size:]
+	}
+
+	catalog := make(map[string]map[string]string)
+	for i, nl := 0, dec.uvarint(); uint64(i) < nl && dec.err == nil; i++ {
+		lang := dec.str()
+		msgs := make(map[string]string)
+		for j, nk := 0, dec.uvarint(); uint64(j) < nk && dec.err == nil; j++ {
+			k := dec.str()
+			msgs[k] = dec.str()
+		}
+		catalog[lang] = msgs
+	}
+
+	templates := make(map[string]TemplateAST)
+	for i, nt := 0, dec.uvarint(); uint64(i) < nt && dec.err == nil; i++ {
+		tpl := dec.str()
+		var ast TemplateAST
+		for j, nn := 0, dec.uvarint(); uint64(j) < nn && dec.err == nil; j++ {
+			switch dec.byte() {
+			case binaryTextNode:
+				ast = append(ast, &TextNode{Text: dec.str()})
+			case binaryPlaceholderNode:
+				ph := &PlaceholderNode{Path: dec.str()}
+				for k, nf := 0, dec.uvarint(); uint64(k) < nf && dec.err == nil; k++ {
+					name := dec.str()
+					ph.Formatters = append(ph.Formatters, Formatter{Name: name, Arg: dec.str()})
+				}
+				if dec.byte() == 1 {
+					ph.Cond = &Conditional{Op: dec.str(), TestValue: dec.str(), TrueExpr: dec.str(), FalseExpr: dec.str()}
+				}
+				ast = append(ast, ph)
+			default:
+				dec.err = errCorruptBinary
+			}
+		}
+		templates[tpl] = ast
+	}
+	if dec.err != nil || len(dec.data) != 0 {
+		return fmt.Errorf("load %s: %w", name, errCorruptBinary)
+	}
+
+	files := make([]*LocaleFile, 0, len(catalog))
+	for lang, msgs := range catalog {
+		files = append(files, &LocaleFile{Path: name, Language: lang, Messages: msgs})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Language < files[j].Language })
+	if err := b.registerFiles(files); err != nil {
+		return err
+	}
+	b.addTemplates(templates)
+	return nil
+}
+
+// addTemplates 把预解析的模板加入本 Bundle 的模板表。
+// 模板表写时复制，翻译时无锁读取
+func (b *Bundle) addTemplates(templates map[string]TemplateAST) {
+	if len(templates) == 0 {
+		return
+	}
+	b.wmu.Lock()
+	defer b.wmu.Unlock()
+	merged := make(map[string]TemplateAST, len(templates))
+	if prev := b.templates.Load(); prev != nil {
+		for tpl, ast := range *prev {
+			merged[tpl] = ast
+		}
+	}
+	for tpl, ast := range templates {
+		merged[tpl] = ast
+	}
+	b.templates.Store(&merged)
+}
+
+// render 渲染一条翻译：优先使用 LoadBinary 为本 Bundle 预解析的模板，否则走全局的 RenderTemplate
+func (b *Bundle) render(tpl string, args map[string]any) (string, error) {
+	if m := b.templates.Load(); m != nil {
+		if ast, ok := (*m)[tpl]; ok {
+			return ast.Eval(args)
+		}
+	}
+	return RenderTemplate(tpl, args)
+}
+
+// collectBinaryTemplate 解析 tpl 以及条件表达式中的子模板；
+// 解析失败的模板不写入，运行时与未预解析时的行为一致
+func collectBinaryTemplate(templates map[string]TemplateAST, tpl string) {
+	if _, ok := templates[tpl]; ok {
+		return
+	}
+	ast, err := ParseTemplate(tpl)
+	if err != nil {
+		return
+	}
+	templates[tpl] = ast
+	for _, node := range ast {
+		if ph, ok := node.(*PlaceholderNode); ok && ph.Cond != nil {
+			collectBinaryTemplate(templates, ph.Cond.TrueExpr)
+			collectBinaryTemplate(templates, ph.Cond.FalseExpr)
+		}
+	}
+}
+
+// binaryEncoder 维护字符串表
+type binaryEncoder struct {
+	strings []string
+	index   map[string]uint64
+}
+
+func (e *binaryEncoder) intern(s string) {
+	if _, ok := e.index[s]; !ok {
+		e.index[s] = uint64(len(e.strings))
+		e.strings = append(e.strings, s)
+	}
+}
+
+// binaryDecoder 顺序读取二进制内容，第一次出错后的读取都返回零值
+type binaryDecoder struct {
+	data    []byte
+	strings []string
+	err     error
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.err = errCorruptBinary
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *binaryDecoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.data) == 0 {
+		d.err = errCorruptBinary
+		return 0
+	}
+	c := d.data[0]
+	d.data = d.data[1:]
+	return c
+}
+
+func (d *binaryDecoder) str() string {
+	i := d.uvarint()
+	if d.err != nil {
+		return ""
+	}
+	if i >= uint64(len(d.strings)) {
+		d.err = errCorruptBinary
+		return ""
+	}
+	return d.strings[i]
+}
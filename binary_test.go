@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundle_Binary(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.yaml": {Data: []byte(`language: en
+messages:
+  user:
+    login:
+      success: "Welcome {name}"
+  items: "{count | eq:0?No items:{count} items}"
+  price: "{amount | number:2}"
+`)},
+		"zh-CN.json": {Data: []byte(`{"language": "zh-CN", "messages": {"user": {"login": {"success": "欢迎 {name}"}}}}`)},
+	}
+	src := New(Config{DefaultLang: "en"})
+	if err := src.LoadFS(fsys, "."); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.WriteBinary(&buf); err != nil {
+		t.Fatalf("WriteBinary: %v", err)
+	}
+	data := buf.Bytes()
+
+	dst := New(Config{DefaultLang: "en"})
+	if err := dst.LoadBinary("locales.bin", bytes.NewReader(data)); err != nil {
+		t.Fatalf("LoadBinary: %v", err)
+	}
+	got, _ := copyStore(dst.store)
+	want, _ := copyStore(src.store)
+	if !reflect.DeepEqual(got.msgs, want.msgs) {
+		t.Fatalf("messages = %v, want %v", got.msgs, want.msgs)
+	}
+	args := map[string]any{"name": "Ann", "count": 0, "amount": 3.5}
+	for _, key := range []string{"user.login.success", "items", "price"} {
+		if got, want := dst.Locale("zh-CN").T(key, args), src.Locale("zh-CN").T(key, args); got != want {
+			t.Fatalf("T(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	t.Run("Binary_Checksum", func(t *testing.T) {
+		bad := append([]byte(nil), data...)
+		bad[len(bad)/2] ^= 0xff
+		err := New(Config{}).LoadBinary("locales.bin", bytes.NewReader(bad))
+		if err == nil || !strings.Contains(err.Error(), "checksum") {
+			t.Fatalf("expected checksum error, got %v", err)
+		}
+	})
+
+	t.Run("Binary_Version", func(t *testing.T) {
+		bad := append([]byte(nil), data...)
+		bad[len(binaryMagic)] = binaryVersion + 1
+		err := New(Config{}).LoadBinary("locales.bin", bytes.NewReader(bad))
+		if err == nil || !strings.Contains(err.Error(), "version") {
+			t.Fatalf("expected version error, got %v", err)
+		}
+	})
+
+	t.Run("Binary_NotBinary", func(t *testing.T) {
+		if err := New(Config{}).LoadBinary("locales.bin", strings.NewReader("language: en\n")); err == nil {
+			t.Fatal("expected error for YAML input")
+		}
+	})
+	t.Run("Binary_TemplatesPerBundle", func(t *testing.T) {
+		// 改写预解析模板中的文本节点并重新计算校验和
+		bad := bytes.Replace(data, []byte("\x08Welcome "), []byte("\x08Hijackd "), 1)
+		if bytes.Equal(bad, data) {
+			t.Fatal("text node not found in binary")
+		}
+		bad = binary.LittleEndian.AppendUint32(bad[:len(bad)-4:len(bad)-4], crc32.ChecksumIEEE(bad[:len(bad)-4]))
+
+		loaded := New(Config{DefaultLang: "en"})
+		if err := loaded.LoadBinary("locales.bin", bytes.NewReader(bad)); err != nil {
+			t.Fatalf("LoadBinary: %v", err)
+		}
+		if got := loaded.Locale("en").T("user.login.success", args); got != "Hijackd Ann" {
+			t.Fatalf("T = %q", got)
+		}
+		clone, err := loaded.Clone()
+		if err != nil {
+			t.Fatalf("Clone: %v", err)
+		}
+		if got := clone.Locale("en").T("user.login.success", args); got != "Hijackd Ann" {
+			t.Fatalf("clone T = %q", got)
+		}
+
+		// 其他 Bundle 和全局模板缓存不受影响
+		if got, _ := RenderTemplate("Welcome {name}", args); got != "Welcome Ann" {
+			t.Fatalf("RenderTemplate = %q", got)
+		}
+		other := New(Config{DefaultLang: "en"})
+		other.RegisterMessages("en", map[string]string{"user.login.success": "Welcome {name}"})
+		if got := other.Locale("en").T("user.login.success", args); got != "Welcome Ann" {
+			t.Fatalf("other bundle T = %q", got)
+		}
+	})
+
+	t.Run("Binary_Conflict", func(t *testing.T) {
+		b := New(Config{DefaultLang: "en", ConflictPolicy: ConflictError})
+		if err := b.LoadFS(fsys, "."); err != nil {
+			t.Fatalf("LoadFS: %v", err)
+		}
+		err := b.LoadBinary("locales.bin", bytes.NewReader(data))
+		var conflict Conflict
+		if !errors.As(err, &conflict) {
+			t.Fatalf("expected Conflict, got %v", err)
+		}
+		if conflict.PrevFile != "en.yaml" || conflict.File != "locales.bin" {
+			t.Fatalf("conflict = %+v", conflict)
+		}
+	})
+}
@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lifei6671/i18n"
+)
+
+// param 访问函数的一个参数，对应模板中的一个占位符路径
+type param struct {
+	path  string // 占位符路径，如 "user.name"
+	name  string // Go 参数名，如 "userName"
+	plain bool   // 所有出现的位置都没有格式化器和条件表达式
+}
+
+// GenerateAccessors 为默认语言中的每个 key 生成一个类型化的访问函数，例如
+//
+//	func UserLoginSuccess(loc *i18n.Locale, name string) string
+//
+// 参数由默认语言模板中的占位符路径推导（包括条件表达式中的子模板），按首次出现的顺序排列；
+// 只以纯文本输出的占位符参数类型为 string，带格式化器或条件表达式的为 any。
+// 点分隔的路径会组装成嵌套的 map，例如 {user.name} 生成参数 userName，
+// 同时使用 {user} 与 {user.name} 时只生成 user 一个参数。
+// key 改名或参数变化后重新生成，调用方会在编译期报错。
+func GenerateAccessors(opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+	if opts.DefaultLang == "" {
+		return nil, fmt.Errorf("default language is required")
+	}
+	bundle, err := load(opts)
+	if err != nil {
+		return nil, err
+	}
+	store := bundle.Store()
+	keys, err := store.Keys(opts.DefaultLang)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no messages for default language %s", opts.DefaultLang)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18ngen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", opts.Package)
+	buf.WriteString("import \"github.com/lifei6671/i18n\"\n")
+
+	funcs := make(map[string]string) // 函数名 -> key
+	for _, key := range keys {
+		text, _, err := store.Get(opts.DefaultLang, key)
+		if err != nil {
+			return nil, err
+		}
+		if err := validate(bundle, opts.DefaultLang, key, text); err != nil {
+			return nil, err
+		}
+		fn := exportedName(key)
+		if prev, ok := funcs[fn]; ok {
+			return nil, fmt.Errorf("keys %q and %q both map to function %s", prev, key, fn)
+		}
+		funcs[fn] = key
+
+		params, err := templateParams(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", opts.DefaultLang, key, err)
+		}
+		writeAccessor(&buf, fn, key, text, params)
+	}
+	return format.Source(buf.Bytes())
+}
+
+func writeAccessor(buf *bytes.Buffer, fn, key, text string, params []param) {
+	fmt.Fprintf(buf, "\n// %s 翻译 %s，默认语言的模板为 %s\n", fn, strconv.Quote(key), strconv.Quote(text))
+	fmt.Fprintf(buf, "func %s(loc *i18n.Locale", fn)
+	for _, p := range params {
+		typ := "any"
+		if p.plain {
+			typ = "string"
+		}
+		fmt.Fprintf(buf, ", %s %s", p.name, typ)
+	}
+	buf.WriteString(") string {\n")
+	if len(params) == 0 {
+		fmt.Fprintf(buf, "\treturn loc.T(%s, nil)\n}\n", strconv.Quote(key))
+		return
+	}
+	fmt.Fprintf(buf, "\treturn loc.T(%s, %s)\n}\n", strconv.Quote(key), argsLiteral(params))
+}
+
+// argNode 按点分隔路径组装参数 map
+type argNode struct {
+	names    []string
+	children map[string]*argNode
+	param    string
+}
+
+// argsLiteral 生成传给 Locale.T 的参数 map 字面量
+func argsLiteral(params []param) string {
+	root := &argNode{children: map[string]*argNode{}}
+	for _, p := range params {
+		n := root
+		for _, seg := range strings.Split(p.path, ".") {
+			child, ok := n.children[seg]
+			if !ok {
+				child = &argNode{children: map[string]*argNode{}}
+				n.children[seg] = child
+				n.names = append(n.names, seg)
+			}
+			n = child
+		}
+		n.param = p.name
+	}
+	return root.literal()
+}
+
+func (n *argNode) literal() string {
+	if n.param != "" {
+		return n.param
+	}
+	parts := make([]string, 0, len(n.names))
+	for _, name := range n.names {
+		parts = append(parts, strconv.Quote(name)+": "+n.children[name].literal())
+	}
+	return "map[string]any{" + strings.Join(parts, ", ") + "}"
+}
+
+// templateParams 收集模板（包括条件表达式中的子模板）用到的占位符路径
+func templateParams(tpl string) ([]param, error) {
+	var (
+		order []string
+		plain = make(map[string]bool)
+	)
+	var walk func(tpl string) error
+	walk = func(tpl string) error {
+		ast, err := i18n.ParseTemplate(tpl)
+		if err != nil {
+			return err
+		}
+		for _, node := range ast {
+			ph, ok := node.(*i18n.PlaceholderNode)
+			if !ok {
+				continue
+			}
+			isPlain := len(ph.Formatters) == 0 && ph.Cond == nil
+			if prev, seen := plain[ph.Path]; seen {
+				plain[ph.Path] = prev && isPlain
+			} else {
+				order = append(order, ph.Path)
+				plain[ph.Path] = isPlain
+			}
+			if ph.Cond != nil {
+				if err := walk(ph.Cond.TrueExpr); err != nil {
+					return err
+				}
+				if err := walk(ph.Cond.FalseExpr); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(tpl); err != nil {
+		return nil, err
+	}
+
+	params := make([]param, 0, len(order))
+	names := make(map[string]string)
+	for _, p := range order {
+		// 路径的前缀本身也是占位符时，由前缀参数整体传入
+		covered, hasChildren := false, false
+		for _, q := range order {
+			if strings.HasPrefix(p, q+".") {
+				covered = true
+			}
+			if strings.HasPrefix(q, p+".") {
+				hasChildren = true
+			}
+		}
+		if covered {
+			continue
+		}
+		name := paramName(p)
+		if prev, ok := names[name]; ok {
+			return nil, fmt.Errorf("placeholders %q and %q both map to parameter %s", prev, p, name)
+		}
+		names[name] = p
+		params = append(params, param{path: p, name: name, plain: plain[p] && !hasChildren})
+	}
+	return params, nil
+}
+
+// exportedName 把 key 转为导出的函数名："billing:invoice.title" -> "BillingInvoiceTitle"
+func exportedName(key string) string {
+	name := camelCase(key)
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Msg" + name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// paramName 把占位符路径转为参数名："user.name" -> "userName"
+func paramName(path string) string {
+	name := camelCase(path)
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "arg" + name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+	name = string(r)
+	// 避开关键字、生成代码中用到的标识符，以及 string、any 等预声明标识符（否则会遮蔽参数类型）
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil || name == "loc" || name == "i18n" {
+		name += "_"
+	}
+	return name
+}
+
+// camelCase 以非字母数字字符切分 s，并把除第一段以外每段的首字母大写
+func camelCase(s string) string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i := 1; i < len(fields); i++ {
+		r := []rune(fields[i])
+		r[0] = unicode.ToUpper(r[0])
+		fields[i] = string(r)
+	}
+	return strings.Join(fields, "")
+}
@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+
+	"github.com/lifei6671/i18n"
+)
+
+// Options 代码生成的配置
+type Options struct {
+	Dir       string // 翻译目录
+	Namespace string // 目录下没有声明 namespace 的文件归入该命名空间
+	Package   string // 生成文件的包名
+	Func      string // 生成的注册函数名，默认 Register
+
+	// DefaultLang 生成类型化访问函数时，以该语言的翻译推导参数（见 GenerateAccessors）
+	DefaultLang string
+
+	// Config 加载翻译时使用的配置（ConflictPolicy、OnConflict、签名校验等），应与运行时的 Bundle 保持一致
+	Config i18n.Config
+}
+
+// Generate 加载 opts.Dir 中的翻译，生成注册全部翻译的 Go 源码。
+// 翻译按运行时相同的规则加载（extends/include、命名空间，以及按 opts.Config 处理冲突），
+// 每个模板都会预先解析为 TemplateAST 字面量，运行时既不需要解析 YAML 也不需要 ParseTemplate。
+// 任一模板无法通过 ValidateTemplate 时返回错误，使 go generate 失败。
+func Generate(opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+	if opts.Func == "" {
+		opts.Func = "Register"
+	}
+
+	bundle, err := load(opts)
+	if err != nil {
+		return nil, err
+	}
+	store := bundle.Store()
+
+	templates := make(map[string]i18n.TemplateAST)
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18ngen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", opts.Package)
+	buf.WriteString("import \"github.com/lifei6671/i18n\"\n\n")
+	fmt.Fprintf(&buf, "// %s 注册生成的翻译，并把预先解析的模板放入 AST 缓存\n", opts.Func)
+	fmt.Fprintf(&buf, "func %s(b *i18n.Bundle) error {\n", opts.Func)
+	buf.WriteString("\tfor tpl, ast := range templates {\n\t\ti18n.RegisterTemplate(tpl, ast)\n\t}\n")
+	buf.WriteString("\tfor lang, msgs := range messages {\n\t\tif err := b.RegisterMessages(lang, msgs); err != nil {\n\t\t\treturn err\n\t\t}\n\t}\n\treturn nil\n}\n\n")
+
+	langs, err := store.Languages()
+	if err != nil {
+		return nil, err
+	}
+	buf.WriteString("var messages = map[string]map[string]string{\n")
+	for _, lang := range langs {
+		fmt.Fprintf(&buf, "\t%s: {\n", strconv.Quote(lang))
+		keys, err := store.Keys(lang)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			text, _, err := store.Get(lang, key)
+			if err != nil {
+				return nil, err
+			}
+			if err := validate(bundle, lang, key, text); err != nil {
+				return nil, err
+			}
+			if err := collectTemplate(templates, text); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", lang, key, err)
+			}
+			fmt.Fprintf(&buf, "\t\t%s: %s,\n", strconv.Quote(key), strconv.Quote(text))
+		}
+		buf.WriteString("\t},\n")
+	}
+	buf.WriteString("}\n\n")
+
+	tpls := make([]string, 0, len(templates))
+	for tpl := range templates {
+		tpls = append(tpls, tpl)
+	}
+	sort.Strings(tpls)
+	buf.WriteString("var templates = map[string]i18n.TemplateAST{\n")
+	for _, tpl := range tpls {
+		fmt.Fprintf(&buf, "\t%s: %s,\n", strconv.Quote(tpl), astLiteral(templates[tpl]))
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// load 按运行时相同的规则加载翻译目录
+func load(opts Options) (*i18n.Bundle, error) {
+	bundle := i18n.New(opts.Config)
+	if err := bundle.LoadNamespaceDir(opts.Namespace, opts.Dir); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// validate 检查模板语法，错误信息带上定义该翻译的位置
+func validate(bundle *i18n.Bundle, lang, key, text string) error {
+	if err := i18n.ValidateTemplate(text); err != nil {
+		if src, ok := bundle.Source(lang, key); ok {
+			return fmt.Errorf("%s: %s %s: %w", src, lang, key, err)
+		}
+		return fmt.Errorf("%s %s: %w", lang, key, err)
+	}
+	return nil
+}
+
+// collectTemplate 解析 tpl 以及条件表达式中的子模板（运行时通过 RenderTemplate 渲染）
+func collectTemplate(templates map[string]i18n.TemplateAST, tpl string) error {
+	if _, ok := templates[tpl]; ok {
+		return nil
+	}
+	ast, err := i18n.ParseTemplate(tpl)
+	if err != nil {
+		return err
+	}
+	templates[tpl] = ast
+	for _, node := range ast {
+		if ph, ok := node.(*i18n.PlaceholderNode); ok && ph.Cond != nil {
+			if err := collectTemplate(templates, ph.Cond.TrueExpr); err != nil {
+				return err
+			}
+			if err := collectTemplate(templates, ph.Cond.FalseExpr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// astLiteral 把 TemplateAST 输出为 Go 字面量
+func astLiteral(ast i18n.TemplateAST) string {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, node := range ast {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		switch n := node.(type) {
+		case *i18n.TextNode:
+			fmt.Fprintf(&buf, "&i18n.TextNode{Text: %s}", strconv.Quote(n.Text))
+		case *i18n.PlaceholderNode:
+			fmt.Fprintf(&buf, "&i18n.PlaceholderNode{Path: %s", strconv.Quote(n.Path))
+			if len(n.Formatters) > 0 {
+				buf.WriteString(", Formatters: []i18n.Formatter{")
+				for j, f := range n.Formatters {
+					if j > 0 {
+						buf.WriteString(", ")
+					}
+					fmt.Fprintf(&buf, "{Name: %s, Arg: %s}", strconv.Quote(f.Name), strconv.Quote(f.Arg))
+				}
+				buf.WriteString("}")
+			}
+			if c := n.Cond; c != nil {
+				fmt.Fprintf(&buf, ", Cond: &i18n.Conditional{Op: %s, TestValue: %s, TrueExpr: %s, FalseExpr: %s}",
+					strconv.Quote(c.Op), strconv.Quote(c.TestValue), strconv.Quote(c.TrueExpr), strconv.Quote(c.FalseExpr))
+			}
+			buf.WriteString("}")
+		default:
+			// ParseTemplate 只会生成上面两种节点
+			panic(fmt.Sprintf("i18ngen: unsupported node type %T", node))
+		}
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lifei6671/i18n"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// checkGolden 比较生成结果与 testdata 中的 golden 文件，-update 时改为写入
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file: %v (run go test -update)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("output differs from %s (run go test -update to accept):\n%s", path, got)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate(Options{Dir: "testdata/locales", Package: "msgs"})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	checkGolden(t, "register.golden", src)
+}
+
+func TestGenerate_ConflictPolicy(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{
+		"a/en.yaml": "language: en\nmessages:\n  title: A\n",
+		"b/en.yaml": "language: en\nmessages:\n  title: B\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := Generate(Options{Dir: dir, Package: "msgs"}); err != nil {
+		t.Fatalf("default policy should not fail: %v", err)
+	}
+	_, err := Generate(Options{Dir: dir, Package: "msgs", Config: i18n.Config{ConflictPolicy: i18n.ConflictError}})
+	var c i18n.Conflict
+	if !errors.As(err, &c) || c.Key != "title" {
+		t.Fatalf("expected a conflict on title, got %v", err)
+	}
+}
+
+func TestGenerate_InvalidTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "en.yaml"), []byte("language: en\nmessages:\n  bad: \"{n | nosuch}\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Generate(Options{Dir: dir, Package: "msgs"}); err == nil {
+		t.Fatal("expected an error for an unknown formatter")
+	}
+}
+
+func TestGenerateAccessors(t *testing.T) {
+	src, err := GenerateAccessors(Options{Dir: "testdata/accessors", Package: "msgs", DefaultLang: "en"})
+	if err != nil {
+		t.Fatalf("GenerateAccessors: %v", err)
+	}
+	checkGolden(t, "accessors.golden", src)
+}
@@ -0,0 +1,68 @@
+// Command i18ngen 把翻译目录编译为 Go 源码，通常配合 go:generate 使用：
+//
+//	//go:generate go run github.com/lifei6671/i18n/cmd/i18ngen -d ./locales -o i18n_gen.go
+//
+// 指定 -accessors 时改为生成每个 key 的类型化访问函数，参数由 -lang 语言的模板推导：
+//
+//	//go:generate go run github.com/lifei6671/i18n/cmd/i18ngen -d ./locales -accessors -lang en -o msgs_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/lifei6671/i18n"
+	"github.com/lifei6671/i18n/cmd/i18ngen/generator"
+)
+
+func main() {
+	dir := flag.String("d", "./i18n/locales", "directory of YAML/JSON locale files")
+	ns := flag.String("ns", "", "default namespace for files that do not declare one")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file (defaults to $GOPACKAGE)")
+	out := flag.String("o", "i18n_gen.go", "output file")
+	fn := flag.String("func", "Register", "name of the generated register function")
+	accessors := flag.Bool("accessors", false, "generate typed accessor functions instead of the message catalog")
+	lang := flag.String("lang", "en", "default language used to derive accessor parameters")
+	conflict := flag.String("conflict", "last", "policy for keys defined in several files: last, first, warn or error")
+	flag.Parse()
+
+	cfg := i18n.Config{
+		OnConflict: func(c i18n.Conflict) { fmt.Fprintln(os.Stderr, "warning:", c) },
+	}
+	switch *conflict {
+	case "last":
+		cfg.ConflictPolicy = i18n.ConflictLastWins
+	case "first":
+		cfg.ConflictPolicy = i18n.ConflictFirstWins
+	case "warn":
+		cfg.ConflictPolicy = i18n.ConflictWarn
+	case "error":
+		cfg.ConflictPolicy = i18n.ConflictError
+	default:
+		fmt.Println("Error: unknown -conflict policy", *conflict)
+		os.Exit(2)
+	}
+
+	opts := generator.Options{
+		Dir:         *dir,
+		Namespace:   *ns,
+		Package:     *pkg,
+		Func:        *fn,
+		DefaultLang: *lang,
+		Config:      cfg,
+	}
+	generate := generator.Generate
+	if *accessors {
+		generate = generator.GenerateAccessors
+	}
+	src, err := generate(opts)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
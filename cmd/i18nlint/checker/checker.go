@@ -2,47 +2,124 @@ package checker
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
 	"sort"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/lifei6671/i18n"
 )
 
-type LangFile struct {
-	Language string            `yaml:"language"`
-	Messages map[string]string `yaml:"messages"`
+// LangFile 与运行时共用同一份解析结果
+type LangFile = i18n.LocaleFile
+
+// Coverage 某个语言的有效覆盖情况：自身定义的 key 与通过 extends 继承的 key
+type Coverage struct {
+	Extends   string // 父语言，没有 extends 时为空
+	Own       int
+	Inherited int
 }
 
 type Result struct {
 	Languages     []string
+	Namespaces    []string // "" 表示全局命名空间
 	MissingKeys   map[string][]string
 	RedundantKeys map[string][]string
-	SyntaxErrors  map[string]map[string]error // lang -> key -> err
-	AllKeys       []string
+	SyntaxErrors  map[string]map[string]error       // lang -> key -> err
+	AllKeys       []string                          // key 带命名空间前缀，如 "billing:invoice.title"
+	Sources       map[string]map[string]i18n.Source // lang -> key -> 定义位置
+	// Coverage 各语言的有效覆盖情况，缺失/冗余 key 均按继承后的有效翻译计算
+	Coverage map[string]Coverage
+
+	// Warnings 不影响 -fail 的提示，例如翻译超过 maxLength；lang -> key -> warning
+	Warnings map[string]map[string]string
+	// DeprecatedKeys 在任一语言中被标记为 deprecated 的 key
+	DeprecatedKeys []string
+	// Conflicts 多个文件为同一语言定义了同一 key
+	Conflicts []i18n.Conflict
+	// DeprecatedUsages 代码中仍在使用的 deprecated key：key -> ["file:line"]，由 CheckDeprecatedUsage 填充
+	DeprecatedUsages map[string][]string
 }
 
 // CheckLocales performs:
 //  1. key alignment check (missing / redundant)
 //  2. template syntax check via i18n.ValidateTemplate()
 func CheckLocales(dir string) (*Result, error) {
-	files, err := scanYAML(dir)
+	return CheckLocalesFS(os.DirFS(dir), ".")
+}
+
+// CheckLocalesNS 与 CheckLocales 相同，目录下没有声明 namespace 的文件归入 ns
+func CheckLocalesNS(dir, ns string) (*Result, error) {
+	files, err := i18n.ReadLocaleDir(ns, dir)
+	if err != nil {
+		return nil, err
+	}
+	return checkFiles(files), nil
+}
+
+// CheckLocalesFS 与 CheckLocales 相同，但从任意 fs.FS 中读取翻译文件
+func CheckLocalesFS(fsys fs.FS, root string) (*Result, error) {
+	files, err := i18n.ReadLocaleFS(fsys, root)
 	if err != nil {
 		return nil, err
 	}
+	return checkFiles(files), nil
+}
 
+func checkFiles(files []*LangFile) *Result {
 	langKeys := make(map[string]map[string]struct{})
 	allKeysSet := make(map[string]struct{})
+	nsSet := make(map[string]struct{})
+
+	sources := make(map[string]map[string]i18n.Source)
+	coverage := make(map[string]Coverage)
+	var conflicts []i18n.Conflict
 
 	for _, file := range files {
-		kset := make(map[string]struct{})
+		// 同一语言可以分散在多个文件（多个命名空间）中
+		kset := langKeys[file.Language]
+		if kset == nil {
+			kset = make(map[string]struct{})
+			langKeys[file.Language] = kset
+		}
+		if sources[file.Language] == nil {
+			sources[file.Language] = make(map[string]i18n.Source)
+		}
+		cov := coverage[file.Language]
+		if file.Extends != "" {
+			cov.Extends = file.Extends
+		}
 		for k := range file.Messages {
-			kset[k] = struct{}{}
-			allKeysSet[k] = struct{}{}
+			key := i18n.NamespacedKey(file.Namespace, k)
+			kset[key] = struct{}{}
+			allKeysSet[key] = struct{}{}
+
+			src, ok := file.Sources[k]
+			if !ok {
+				src = i18n.Source{File: file.Path}
+			}
+			// 继承来的 key 已在展开时去重，不参与冲突检测
+			if file.InheritedFrom != "" {
+				cov.Inherited++
+				sources[file.Language][key] = src
+				continue
+			}
+			// 多个文件定义同一 key 时只计一次，重复定义作为冲突报告
+			if prev, ok := sources[file.Language][key]; ok {
+				conflicts = append(conflicts, i18n.Conflict{
+					Language: file.Language,
+					Key:      key,
+					PrevFile: prev.File,
+					PrevLine: prev.Line,
+					File:     src.File,
+					Line:     src.Line,
+				})
+			} else {
+				cov.Own++
+				sources[file.Language][key] = src
+			}
 		}
-		langKeys[file.Language] = kset
+		coverage[file.Language] = cov
+		nsSet[file.Namespace] = struct{}{}
 	}
 
 	allKeys := make([]string, 0, len(allKeysSet))
@@ -70,63 +147,97 @@ func CheckLocales(dir string) (*Result, error) {
 	// 新增：语法检查
 	syntaxErrors := make(map[string]map[string]error)
 	for _, file := range files {
+		// 继承来的翻译由父语言负责检查
+		if file.InheritedFrom != "" {
+			continue
+		}
 		for key, msg := range file.Messages {
 			if err := i18n.ValidateTemplate(msg); err != nil {
 				if syntaxErrors[file.Language] == nil {
 					syntaxErrors[file.Language] = make(map[string]error)
 				}
-				syntaxErrors[file.Language][key] = err
+				syntaxErrors[file.Language][i18n.NamespacedKey(file.Namespace, key)] = err
 			}
 		}
 	}
 
+	// 元信息检查：maxLength 取各语言中声明的最小值，对所有语言生效
+	maxLength := make(map[string]int)
+	deprecated := make(map[string]struct{})
+	for _, file := range files {
+		for k, m := range file.Meta {
+			key := i18n.NamespacedKey(file.Namespace, k)
+			if m.MaxLength > 0 && (maxLength[key] == 0 || m.MaxLength < maxLength[key]) {
+				maxLength[key] = m.MaxLength
+			}
+			if m.Deprecated {
+				deprecated[key] = struct{}{}
+			}
+		}
+	}
+	warnings := make(map[string]map[string]string)
+	for _, file := range files {
+		if file.InheritedFrom != "" {
+			continue
+		}
+		for k, msg := range file.Messages {
+			key := i18n.NamespacedKey(file.Namespace, k)
+			limit := maxLength[key]
+			if n := len([]rune(msg)); limit > 0 && n > limit {
+				if warnings[file.Language] == nil {
+					warnings[file.Language] = make(map[string]string)
+				}
+				warnings[file.Language][key] = fmt.Sprintf("length %d exceeds maxLength %d", n, limit)
+			}
+		}
+	}
+	deprecatedKeys := make([]string, 0, len(deprecated))
+	for k := range deprecated {
+		deprecatedKeys = append(deprecatedKeys, k)
+	}
+	sort.Strings(deprecatedKeys)
+
 	langs := make([]string, 0, len(langKeys))
 	for l := range langKeys {
 		langs = append(langs, l)
 	}
 	sort.Strings(langs)
 
+	namespaces := make([]string, 0, len(nsSet))
+	for ns := range nsSet {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Language != conflicts[j].Language {
+			return conflicts[i].Language < conflicts[j].Language
+		}
+		return conflicts[i].Key < conflicts[j].Key
+	})
+
 	return &Result{
 		Languages:     langs,
+		Namespaces:    namespaces,
 		MissingKeys:   missing,
 		RedundantKeys: redundant,
 		SyntaxErrors:  syntaxErrors,
 		AllKeys:       allKeys,
-	}, nil
-}
-
-func scanYAML(dir string) ([]LangFile, error) {
-	var res []LangFile
-
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-
-		ext := filepath.Ext(path)
-		if ext != ".yaml" && ext != ".yml" {
-			return nil
-		}
+		Sources:       sources,
+		Coverage:      coverage,
 
-		data, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("read %s: %w", path, err)
-		}
-
-		var lf LangFile
-		if err := yaml.Unmarshal(data, &lf); err != nil {
-			return fmt.Errorf("yaml error %s: %w", path, err)
-		}
-		if lf.Language == "" {
-			return fmt.Errorf("file %s missing 'language' field", path)
-		}
-
-		res = append(res, lf)
-		return nil
-	})
+		Conflicts:      conflicts,
+		Warnings:       warnings,
+		DeprecatedKeys: deprecatedKeys,
+	}
+}
 
-	return res, err
+// GroupByNamespace 把带命名空间前缀的 key 按命名空间分组，key 中的前缀会被去掉
+func GroupByNamespace(keys []string) map[string][]string {
+	groups := make(map[string][]string)
+	for _, k := range keys {
+		ns, key := i18n.SplitNamespace(k)
+		groups[ns] = append(groups[ns], key)
+	}
+	return groups
 }
@@ -0,0 +1,85 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/lifei6671/i18n"
+)
+
+func TestCheckLocalesFS_MaxLength(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.yaml": {Data: []byte(`language: en
+version: 2
+messages:
+  checkout.button:
+    text: "Pay now"
+    maxLength: 10
+  user.old_greeting:
+    text: "Hi"
+    deprecated: true
+`)},
+		// zh-CN 声明了更小的 maxLength，对所有语言生效
+		"zh-CN.yaml": {Data: []byte(`language: zh-CN
+version: 2
+messages:
+  checkout.button:
+    text: "立即支付"
+    maxLength: 5
+  user.old_greeting: "你好"
+`)},
+		"de.yaml": {Data: []byte("language: de\nmessages:\n  checkout.button: Jetzt bezahlen\n  user.old_greeting: Hallo\n")},
+	}
+	res, err := CheckLocalesFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("CheckLocalesFS: %v", err)
+	}
+	want := map[string]map[string]string{
+		"de": {"checkout.button": "length 14 exceeds maxLength 5"},
+		"en": {"checkout.button": "length 7 exceeds maxLength 5"},
+	}
+	if !reflect.DeepEqual(res.Warnings, want) {
+		t.Fatalf("Warnings = %v, want %v", res.Warnings, want)
+	}
+	if !reflect.DeepEqual(res.DeprecatedKeys, []string{"user.old_greeting"}) {
+		t.Fatalf("DeprecatedKeys = %v", res.DeprecatedKeys)
+	}
+}
+
+func TestCheckLocalesFS_CoverageAndConflicts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"zh-CN.yaml": {Data: []byte("language: zh-CN\nmessages:\n  hello: 你好\n  bye: 再见\n  color: 颜色\n")},
+		"zh-TW.yaml": {Data: []byte("language: zh-TW\nextends: zh-CN\nmessages:\n  color: 顏色\n")},
+		// 第二个 zh-TW 文件重复定义 color，并定义了自己的 bye
+		"extra/zh-TW.yaml": {Data: []byte("language: zh-TW\nmessages:\n  color: 色彩\n  bye: 再會\n")},
+	}
+	res, err := CheckLocalesFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("CheckLocalesFS: %v", err)
+	}
+
+	want := map[string]Coverage{
+		"zh-CN": {Own: 3},
+		"zh-TW": {Extends: "zh-CN", Own: 2, Inherited: 1},
+	}
+	if !reflect.DeepEqual(res.Coverage, want) {
+		t.Fatalf("Coverage = %+v, want %+v", res.Coverage, want)
+	}
+	// 继承后的有效翻译没有缺失
+	if len(res.MissingKeys) != 0 {
+		t.Fatalf("MissingKeys = %v", res.MissingKeys)
+	}
+
+	wantConflicts := []i18n.Conflict{{
+		Language: "zh-TW",
+		Key:      "color",
+		PrevFile: "extra/zh-TW.yaml",
+		PrevLine: 3,
+		File:     "zh-TW.yaml",
+		Line:     4,
+	}}
+	if !reflect.DeepEqual(res.Conflicts, wantConflicts) {
+		t.Fatalf("Conflicts = %+v, want %+v", res.Conflicts, wantConflicts)
+	}
+}
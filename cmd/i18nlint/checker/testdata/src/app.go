@@ -0,0 +1,10 @@
+package app
+
+func greet(t func(string) string) string {
+	return t("user.old_greeting") + t("user.greeting")
+}
+
+func farewell(t func(string) string) string {
+	// 带命名空间前缀的 key 同样按字面量匹配
+	return t(`billing:invoice.legacy`)
+}
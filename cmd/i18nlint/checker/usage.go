@@ -0,0 +1,66 @@
+package checker
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CheckDeprecatedUsage 扫描 srcDir 下的 Go 源码，找出仍以字符串字面量形式
+// 出现的 deprecated key（res.DeprecatedKeys），结果写入 res.DeprecatedUsages
+func CheckDeprecatedUsage(res *Result, srcDir string) error {
+	res.DeprecatedUsages = make(map[string][]string)
+	if len(res.DeprecatedKeys) == 0 {
+		return nil
+	}
+	keys := make(map[string]struct{}, len(res.DeprecatedKeys))
+	for _, k := range res.DeprecatedKeys {
+		keys[k] = struct{}{}
+	}
+
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != srcDir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			s, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+			if _, ok := keys[s]; ok {
+				pos := fset.Position(lit.Pos())
+				res.DeprecatedUsages[s] = append(res.DeprecatedUsages[s], fmt.Sprintf("%s:%d", pos.Filename, pos.Line))
+			}
+			return true
+		})
+		return nil
+	})
+	for _, locs := range res.DeprecatedUsages {
+		sort.Strings(locs)
+	}
+	return err
+}
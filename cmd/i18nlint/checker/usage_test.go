@@ -0,0 +1,36 @@
+package checker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckDeprecatedUsage(t *testing.T) {
+	res := &Result{DeprecatedKeys: []string{"billing:invoice.legacy", "user.old_greeting", "user.unused"}}
+	dir := filepath.Join("testdata", "src")
+	if err := CheckDeprecatedUsage(res, dir); err != nil {
+		t.Fatalf("CheckDeprecatedUsage: %v", err)
+	}
+	app := filepath.Join(dir, "app.go")
+	want := map[string][]string{
+		"user.old_greeting":      {app + ":4"},
+		"billing:invoice.legacy": {app + ":9"},
+	}
+	// vendor 目录不扫描，未使用的 key 不出现
+	if !reflect.DeepEqual(res.DeprecatedUsages, want) {
+		t.Fatalf("DeprecatedUsages = %v, want %v", res.DeprecatedUsages, want)
+	}
+}
+
+func TestCheckDeprecatedUsage_ParseError(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bad.go"), []byte("package bad\nfunc {"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	res := &Result{DeprecatedKeys: []string{"k"}}
+	if err := CheckDeprecatedUsage(res, dir); err == nil {
+		t.Fatal("expected a parse error")
+	}
+}
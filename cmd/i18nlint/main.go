@@ -4,20 +4,29 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/lifei6671/i18n/cmd/i18nlint/checker"
 )
 
 func main() {
-	dir := flag.String("d", "./i18n/locales", "directory of YAML locale files")
+	dir := flag.String("d", "./i18n/locales", "directory of YAML/JSON locale files")
+	ns := flag.String("ns", "", "default namespace for files that do not declare one")
+	src := flag.String("src", "", "directory of Go sources to scan for deprecated keys")
 	failOnError := flag.Bool("fail", false, "exit with code 1 if any issue found")
 	flag.Parse()
 
-	res, err := checker.CheckLocales(*dir)
+	res, err := checker.CheckLocalesNS(*dir, *ns)
 	if err != nil {
 		fmt.Println("Error:", err)
 		os.Exit(1)
 	}
+	if *src != "" {
+		if err := checker.CheckDeprecatedUsage(res, *src); err != nil {
+			fmt.Println("Error:", err)
+			os.Exit(1)
+		}
+	}
 
 	printResult(res)
 
@@ -29,17 +38,29 @@ func main() {
 func printResult(res *checker.Result) {
 	fmt.Println("=== I18N CHECK RESULT ===")
 	fmt.Println("Languages:", res.Languages)
+	if len(res.Namespaces) > 1 || (len(res.Namespaces) == 1 && res.Namespaces[0] != "") {
+		names := make([]string, 0, len(res.Namespaces))
+		for _, ns := range res.Namespaces {
+			if ns == "" {
+				ns = "(global)"
+			}
+			names = append(names, ns)
+		}
+		fmt.Println("Namespaces:", names)
+	}
 	fmt.Println("Total keys:", len(res.AllKeys))
 
 	for _, lang := range res.Languages {
 		fmt.Printf("\n--- [%s] ---\n", lang)
+		if cov := res.Coverage[lang]; cov.Extends != "" {
+			fmt.Printf("Coverage: %d/%d keys (%d own, %d inherited from %s)\n",
+				cov.Own+cov.Inherited, len(res.AllKeys), cov.Own, cov.Inherited, cov.Extends)
+		}
 
 		// missing keys
 		if arr := res.MissingKeys[lang]; len(arr) > 0 {
 			fmt.Println("Missing keys:")
-			for _, k := range arr {
-				fmt.Println("  -", k)
-			}
+			printKeys(arr)
 		} else {
 			fmt.Println("Missing keys: None")
 		}
@@ -47,9 +68,7 @@ func printResult(res *checker.Result) {
 		// redundant
 		if arr := res.RedundantKeys[lang]; len(arr) > 0 {
 			fmt.Println("Redundant keys:")
-			for _, k := range arr {
-				fmt.Println("  -", k)
-			}
+			printKeys(arr)
 		} else {
 			fmt.Println("Redundant keys: None")
 		}
@@ -58,11 +77,56 @@ func printResult(res *checker.Result) {
 		if errs := res.SyntaxErrors[lang]; len(errs) > 0 {
 			fmt.Println("Syntax errors:")
 			for key, err := range errs {
-				fmt.Printf("  - %s: %v\n", key, err)
+				fmt.Printf("  - %s (%s): %v\n", key, res.Sources[lang][key], err)
 			}
 		} else {
 			fmt.Println("Syntax errors: None")
 		}
+
+		// warnings
+		if warns := res.Warnings[lang]; len(warns) > 0 {
+			fmt.Println("Warnings:")
+			for key, w := range warns {
+				fmt.Printf("  - %s (%s): %s\n", key, res.Sources[lang][key], w)
+			}
+		}
+	}
+
+	if len(res.Conflicts) > 0 {
+		fmt.Println("\n--- Conflicting definitions ---")
+		for _, c := range res.Conflicts {
+			fmt.Println("  -", c.Error())
+		}
+	}
+
+	if len(res.DeprecatedUsages) > 0 {
+		fmt.Println("\n--- Deprecated keys still in use ---")
+		for _, key := range res.DeprecatedKeys {
+			for _, loc := range res.DeprecatedUsages[key] {
+				fmt.Printf("  - %s: %s\n", key, loc)
+			}
+		}
+	}
+}
+
+// printKeys 按命名空间分组输出 key，全局命名空间不输出分组标题
+func printKeys(keys []string) {
+	groups := checker.GroupByNamespace(keys)
+	namespaces := make([]string, 0, len(groups))
+	for ns := range groups {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	for _, ns := range namespaces {
+		indent := "  "
+		if ns != "" {
+			fmt.Printf("  [%s]\n", ns)
+			indent = "    "
+		}
+		for _, k := range groups[ns] {
+			fmt.Println(indent+"-", k)
+		}
 	}
 }
 
@@ -82,5 +146,5 @@ func hasIssues(res *checker.Result) bool {
 			return true
 		}
 	}
-	return false
+	return len(res.Conflicts) > 0
 }
@@ -0,0 +1,154 @@
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"reflect"
+	"sort"
+)
+
+// ConflictPolicy 决定多个文件为同一语言定义同一 key 时如何处理
+type ConflictPolicy int
+
+const (
+	// ConflictLastWins 后加载的文件覆盖先加载的（默认，与旧版本行为一致）
+	ConflictLastWins ConflictPolicy = iota
+	// ConflictFirstWins 保留先加载的定义，忽略后来的
+	ConflictFirstWins
+	// ConflictWarn 与 ConflictLastWins 相同，但每个冲突都会调用 Config.OnConflict
+	ConflictWarn
+	// ConflictError 加载返回 Conflict 错误，同一次加载中的文件都不会被注册
+	ConflictError
+)
+
+// Conflict 描述一次跨文件的 key 冲突
+type Conflict struct {
+	Language string
+	Key      string // 带命名空间前缀
+	PrevFile string // 先定义该 key 的文件
+	PrevLine int    // 为 0 表示没有行号信息
+	File     string // 再次定义该 key 的文件
+	Line     int
+}
+
+func (c Conflict) Error() string {
+	prev, cur := c.PrevFile, c.File
+	if c.PrevLine > 0 {
+		prev = fmt.Sprintf("%s:%d", prev, c.PrevLine)
+	}
+	if c.Line > 0 {
+		cur = fmt.Sprintf("%s:%d", cur, c.Line)
+	}
+	return fmt.Sprintf("conflicting definition of %q for language %s: %s and %s", c.Key, c.Language, prev, cur)
+}
+
+// fsOrigin 返回标识 fsys 的可比较值：引用类型（如 fstest.MapFS）按地址区分，
+// 可比较的值类型（如 os.DirFS、embed.FS）按值区分，同一目录的两次 os.DirFS 视为同一来源
+func fsOrigin(fsys fs.FS) any {
+	v := reflect.ValueOf(fsys)
+	switch v.Kind() {
+	case reflect.Map, reflect.Pointer, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return fsPointer{typ: v.Type(), ptr: v.Pointer()}
+	}
+	if v.Comparable() {
+		return fsys
+	}
+	// 无法比较的值类型只能按类型区分
+	return fsPointer{typ: v.Type()}
+}
+
+// fsPointer 引用类型 fs.FS 的标识
+type fsPointer struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// findConflicts 找出 f 中与 prev 记录的其他文件冲突的 key，按 key 排序。
+// 同一 fs 中的同一文件重新加载不算冲突；手动注册的 key 没有 File，不参与冲突检测
+func findConflicts(f *LocaleFile, prev map[string]Source) []Conflict {
+	var conflicts []Conflict
+	for k := range f.Messages {
+		key := NamespacedKey(f.Namespace, k)
+		p, ok := prev[key]
+		// 继承而来的翻译可以被本语言的显式定义替换，不算冲突
+		if !ok || p.File == "" || p.inherited || (p.File == f.Path && p.origin == f.origin) {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			Language: f.Language,
+			Key:      key,
+			PrevFile: p.File,
+			PrevLine: p.Line,
+			File:     f.Path,
+			Line:     f.Sources[k].Line,
+		})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Key < conflicts[j].Key })
+	return conflicts
+}
+
+// checkConflictsLocked 检查整批文件与已注册的翻译、以及彼此之间是否冲突，返回第一个冲突。
+// 调用方需持有 b.wmu
+func (b *Bundle) checkConflictsLocked(files []*LocaleFile) error {
+	pending := make(map[string]map[string]Source)
+	for _, f := range files {
+		if f.InheritedFrom != "" {
+			continue
+		}
+		prev := pending[f.Language]
+		if prev == nil {
+			prev = make(map[string]Source, len(b.sources[f.Language]))
+			for k, src := range b.sources[f.Language] {
+				prev[k] = src
+			}
+			pending[f.Language] = prev
+		}
+		if conflicts := findConflicts(f, prev); len(conflicts) > 0 {
+			return conflicts[0]
+		}
+		for k := range f.Messages {
+			prev[NamespacedKey(f.Namespace, k)] = f.source(k)
+		}
+	}
+	return nil
+}
+
+// resolveConflictsLocked 找出 msgs 中与其他文件冲突的 key，并按 ConflictPolicy 处理，
+// 返回实际需要注册的翻译以及需要通过 OnConflict 报告的冲突。
+// ConflictError 由 checkConflictsLocked 事先检查。调用方需持有 b.wmu
+func (b *Bundle) resolveConflictsLocked(f *LocaleFile, msgs map[string]string) (map[string]string, []Conflict, error) {
+	// 继承来的翻译只补充缺失的 key
+	if f.InheritedFrom != "" {
+		kept := make(map[string]string, len(msgs))
+		for k, v := range msgs {
+			_, ok, err := b.store.Get(f.Language, k)
+			if err != nil {
+				return nil, nil, fmt.Errorf("i18n: store %s %s: %w", f.Language, k, err)
+			}
+			if !ok {
+				kept[k] = v
+			}
+		}
+		return kept, nil, nil
+	}
+
+	conflicts := findConflicts(f, b.sources[f.Language])
+	if len(conflicts) == 0 {
+		return msgs, nil, nil
+	}
+	switch b.config.ConflictPolicy {
+	case ConflictFirstWins:
+		kept := make(map[string]string, len(msgs))
+		for k, v := range msgs {
+			kept[k] = v
+		}
+		for _, c := range conflicts {
+			delete(kept, c.Key)
+		}
+		return kept, nil, nil
+	case ConflictWarn:
+		return msgs, conflicts, nil
+	default:
+		return msgs, nil, nil
+	}
+}
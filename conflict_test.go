@@ -0,0 +1,121 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func conflictFS() fstest.MapFS {
+	return fstest.MapFS{
+		"a/en.yaml": {Data: []byte("language: en\nmessages:\n  title: A\n  only.a: a\n")},
+		"b/en.yaml": {Data: []byte("language: en\nmessages:\n  title: B\n  only.b: b\n")},
+	}
+}
+
+func loadConflicting(b *Bundle) error {
+	fsys := conflictFS()
+	if err := b.LoadFS(fsys, "a"); err != nil {
+		return err
+	}
+	return b.LoadFS(fsys, "b")
+}
+
+func TestBundle_ConflictPolicy(t *testing.T) {
+	t.Run("Conflict_LastWins", func(t *testing.T) {
+		b := New(Config{})
+		if err := loadConflicting(b); err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if got := b.Locale("en").T("title", nil); got != "B" {
+			t.Fatalf("T: %q", got)
+		}
+	})
+	t.Run("Conflict_FirstWins", func(t *testing.T) {
+		b := New(Config{ConflictPolicy: ConflictFirstWins})
+		if err := loadConflicting(b); err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		loc := b.Locale("en")
+		if got := loc.T("title", nil); got != "A" {
+			t.Fatalf("T: %q", got)
+		}
+		if got := loc.T("only.b", nil); got != "b" {
+			t.Fatalf("non-conflicting keys should still be loaded, got %q", got)
+		}
+	})
+	t.Run("Conflict_Warn", func(t *testing.T) {
+		var got []Conflict
+		b := New(Config{
+			ConflictPolicy: ConflictWarn,
+			OnConflict:     func(c Conflict) { got = append(got, c) },
+		})
+		if err := loadConflicting(b); err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("conflicts: %v", got)
+		}
+		want := Conflict{Language: "en", Key: "title", PrevFile: "a/en.yaml", PrevLine: 3, File: "b/en.yaml", Line: 3}
+		if got[0] != want {
+			t.Fatalf("conflict = %+v, want %+v", got[0], want)
+		}
+	})
+	t.Run("Conflict_Error", func(t *testing.T) {
+		b := New(Config{ConflictPolicy: ConflictError})
+		err := loadConflicting(b)
+		var c Conflict
+		if !errors.As(err, &c) || c.PrevFile != "a/en.yaml" || c.File != "b/en.yaml" {
+			t.Fatalf("err = %v", err)
+		}
+		if got := b.Locale("en").T("only.b", nil); got != "only.b" {
+			t.Fatalf("conflicting file should not be registered, got %q", got)
+		}
+	})
+	t.Run("Conflict_ReloadSameFile", func(t *testing.T) {
+		b := New(Config{ConflictPolicy: ConflictError})
+		fsys := conflictFS()
+		if err := b.LoadFS(fsys, "a"); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.LoadFS(fsys, "a"); err != nil {
+			t.Fatalf("reloading the same file is not a conflict: %v", err)
+		}
+	})
+}
+
+func TestBundle_ConflictAcrossFS(t *testing.T) {
+	en := []byte("language: en\nmessages:\n  title: A\n")
+	first := fstest.MapFS{"en.yaml": {Data: en}}
+	second := fstest.MapFS{"en.yaml": {Data: []byte("language: en\nmessages:\n  title: B\n")}}
+
+	b := New(Config{ConflictPolicy: ConflictError})
+	if err := b.LoadFS(first, "."); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.LoadFS(first, "."); err != nil {
+		t.Fatalf("reloading the same fs is not a conflict: %v", err)
+	}
+	var c Conflict
+	if err := b.LoadFS(second, "."); !errors.As(err, &c) || c.Key != "title" {
+		t.Fatalf("same path in another fs should conflict, got %v", err)
+	}
+}
+
+func TestBundle_ConflictErrorIsAtomic(t *testing.T) {
+	b := New(Config{ConflictPolicy: ConflictError})
+	if err := b.LoadFS(conflictFS(), "a"); err != nil {
+		t.Fatal(err)
+	}
+	// c/de.yaml 先于 c/zz.yaml 被遍历，后者与 a/en.yaml 冲突
+	fsys := fstest.MapFS{
+		"c/de.yaml": {Data: []byte("language: de\nmessages:\n  title: C\n")},
+		"c/zz.yaml": {Data: []byte("language: en\nmessages:\n  title: Z\n")},
+	}
+	if err := b.LoadFS(fsys, "c"); err == nil {
+		t.Fatal("expected a conflict")
+	}
+	if got := b.Locale("de").T("title", nil); got == "C" {
+		t.Fatal("files loaded before the conflicting one should not be registered")
+	}
+}
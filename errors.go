@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissingKey 语言链中的所有语言都没有定义该 key，可用 errors.Is 判断
+var ErrMissingKey = errors.New("i18n: missing translation")
+
+// MissingKeyError 描述一次找不到翻译，errors.Is(err, ErrMissingKey) 为 true
+type MissingKeyError struct {
+	Key   string
+	Langs []string // 查找过的语言链
+}
+
+func (e *MissingKeyError) Error() string {
+	return fmt.Sprintf("i18n: missing translation %q for languages [%s]", e.Key, strings.Join(e.Langs, ", "))
+}
+
+// Is 使 errors.Is(err, ErrMissingKey) 成立
+func (e *MissingKeyError) Is(target error) bool {
+	return target == ErrMissingKey
+}
+
+// RenderError 找到了翻译但模板渲染失败，Err 为 RenderTemplate 返回的错误
+type RenderError struct {
+	Key  string
+	Lang string // 渲染失败的语言
+	Err  error
+	// FallbackLang 开启 Config.RenderFallthrough 后实际使用的语言，为空表示没有语言渲染成功
+	FallbackLang string
+}
+
+func (e *RenderError) Error() string {
+	if e.FallbackLang != "" {
+		return fmt.Sprintf("i18n: render %q (%s): %v; fell back to %s", e.Key, e.Lang, e.Err, e.FallbackLang)
+	}
+	return fmt.Sprintf("i18n: render %q (%s): %v", e.Key, e.Lang, e.Err)
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
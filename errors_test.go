@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocale_Translate(t *testing.T) {
+	bundle := New(Config{DefaultLang: "en"})
+	bundle.RegisterMessages("en", map[string]string{
+		"hello":  "Hello {name}",
+		"broken": "Hi {user.nick}",
+	})
+	bundle.RegisterMessages("zh-CN", map[string]string{"hello": "你好 {name}"})
+	loc := bundle.Locale("zh-CN")
+
+	got, err := loc.Translate("hello", map[string]any{"name": "Ann"})
+	if err != nil || got != "你好 Ann" {
+		t.Fatalf("Translate = %q, %v", got, err)
+	}
+
+	got, err = loc.Translate("missing", nil)
+	if !errors.Is(err, ErrMissingKey) || got != "missing" {
+		t.Fatalf("missing: %q, %v", got, err)
+	}
+	var missing *MissingKeyError
+	if !errors.As(err, &missing) || missing.Key != "missing" || len(missing.Langs) != 2 {
+		t.Fatalf("MissingKeyError: %+v", missing)
+	}
+
+	got, err = loc.Translate("broken", map[string]any{"user": map[string]any{"name": "Ann"}})
+	var renderErr *RenderError
+	if !errors.As(err, &renderErr) {
+		t.Fatalf("expected RenderError, got %v", err)
+	}
+	if renderErr.Key != "broken" || renderErr.Lang != "en" || renderErr.Err == nil {
+		t.Fatalf("RenderError: %+v", renderErr)
+	}
+	if got != "Hi {user.nick}" {
+		t.Fatalf("render failure should return the raw text, got %q", got)
+	}
+
+	// T 保持宽松的行为
+	if got := loc.T("missing", nil); got != "missing" {
+		t.Fatalf("T missing: %q", got)
+	}
+	if got := loc.T("broken", nil); got != "Hi {user.nick}" {
+		t.Fatalf("T broken: %q", got)
+	}
+}
+
+func TestLocale_RenderFallthrough(t *testing.T) {
+	var hooked *RenderError
+	bundle := New(Config{
+		DefaultLang:       "en",
+		Fallbacks:         map[string][]string{"zh-CN": {"zh-CN", "zh", "en"}},
+		RenderFallthrough: true,
+		OnRenderError: func(lang, key string, err error) {
+			errors.As(err, &hooked)
+		},
+	})
+	bundle.RegisterMessages("en", map[string]string{"welcome": "Welcome {user.name}"})
+	bundle.RegisterMessages("zh", map[string]string{"welcome": "欢迎 {user.name | nosuch}"})
+	bundle.RegisterMessages("zh-CN", map[string]string{"welcome": "欢迎 {user.nick}"})
+	args := map[string]any{"user": map[string]any{"name": "Ann"}}
+	loc := bundle.Locale("zh-CN")
+
+	got, err := loc.Translate("welcome", args)
+	var renderErr *RenderError
+	if !errors.As(err, &renderErr) {
+		t.Fatalf("expected RenderError, got %v", err)
+	}
+	if got != "Welcome Ann" || renderErr.Lang != "zh-CN" || renderErr.FallbackLang != "en" {
+		t.Fatalf("Translate = %q, %+v", got, renderErr)
+	}
+
+	if got := loc.T("welcome", args); got != "Welcome Ann" {
+		t.Fatalf("T = %q", got)
+	}
+	if hooked == nil || hooked.Lang != "zh-CN" || hooked.FallbackLang != "en" {
+		t.Fatalf("hook error: %+v", hooked)
+	}
+
+	// 未开启时保持原有行为
+	strict := mustClone(t, bundle)
+	strict.config.RenderFallthrough = false
+	if got := strict.Locale("zh-CN").T("welcome", args); got != "欢迎 {user.nick}" {
+		t.Fatalf("without fallthrough: %q", got)
+	}
+
+	// 第一个语言渲染成功时只查询一次 Store
+	store := &fakeStore{data: make(MessageStore)}
+	counted := New(Config{
+		Fallbacks:         map[string][]string{"zh-CN": {"zh-CN", "zh", "en"}},
+		RenderFallthrough: true,
+		Store:             store,
+	})
+	counted.RegisterMessages("zh-CN", map[string]string{"hi": "你好"})
+	counted.RegisterMessages("en", map[string]string{"hi": "Hi"})
+	store.gets = 0
+	if got := counted.Locale("zh-CN").T("hi", nil); got != "你好" || store.gets != 1 {
+		t.Fatalf("T = %q with %d store lookups, want 1", got, store.gets)
+	}
+}
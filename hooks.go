@@ -0,0 +1,181 @@
+package i18n
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// ReplaceFunc 决定 Locale.T 翻译失败时返回的文本。
+// err 为 *MissingKeyError 或 *RenderError；返回 false 时使用默认文本（key 或原文）
+type ReplaceFunc func(l *Locale, key string, args map[string]any, err error) (string, bool)
+
+// ReplaceKey 返回 key 本身
+func ReplaceKey(_ *Locale, key string, _ map[string]any, _ error) (string, bool) {
+	return key, true
+}
+
+// ReplaceHumanizedKey 把 key 的最后一段转为可读文本："user.login_success" -> "Login success"
+func ReplaceHumanizedKey(_ *Locale, key string, _ map[string]any, _ error) (string, bool) {
+	return humanizeKey(key), true
+}
+
+// ReplaceMarker 返回带标记的 key，便于在界面上发现遗漏的翻译，例如 ReplaceMarker("⟦", "⟧")
+func ReplaceMarker(prefix, suffix string) ReplaceFunc {
+	return func(_ *Locale, key string, _ map[string]any, _ error) (string, bool) {
+		return prefix + key + suffix, true
+	}
+}
+
+// ReplaceDefaultLang 改用 Config.DefaultLang 的翻译；默认语言同样失败时返回默认文本
+func ReplaceDefaultLang(l *Locale, key string, args map[string]any, err error) (string, bool) {
+	var renderErr *RenderError
+	lang := l.bundle.config.DefaultLang
+	if errors.As(err, &renderErr) && renderErr.Lang == lang {
+		return "", false
+	}
+	def := *l
+	def.langs = []string{lang}
+	text, err := def.Translate(key, args)
+	return text, err == nil
+}
+
+// humanizeKey 取 key 的最后一段，下划线与连字符替换为空格，首字母大写
+func humanizeKey(key string) string {
+	_, key = SplitNamespace(key)
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	key = strings.NewReplacer("_", " ", "-", " ").Replace(key)
+	r := []rune(strings.TrimSpace(key))
+	if len(r) == 0 {
+		return key
+	}
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// fail 处理 Locale.T 的翻译失败：按去重与限流规则触发回调，并按 Config.Replace 决定返回的文本
+func (l *Locale) fail(key string, args map[string]any, text string, err error) string {
+	if l.bundle == nil {
+		return text
+	}
+	cfg := &l.bundle.config
+
+	var (
+		missing   *MissingKeyError
+		renderErr *RenderError
+	)
+	switch {
+	case errors.As(err, &missing):
+		if cfg.OnMissingKey != nil && l.bundle.hooks.allow(cfg, "missing\x00"+strings.Join(missing.Langs, ",")+"\x00"+key) {
+			cfg.OnMissingKey(missing.Langs, key)
+		}
+	case errors.As(err, &renderErr):
+		if cfg.OnRenderError != nil && l.bundle.hooks.allow(cfg, "render\x00"+renderErr.Lang+"\x00"+key) {
+			cfg.OnRenderError(renderErr.Lang, key, renderErr)
+		}
+		// 已经回退到其他语言渲染成功
+		if renderErr.FallbackLang != "" {
+			return text
+		}
+	}
+
+	if cfg.Replace != nil {
+		if s, ok := cfg.Replace(l, key, args, err); ok {
+			return s
+		}
+	}
+	return text
+}
+
+// hookLimiter 回调的去重与限流，零值可用。
+//
+// 去重表按时间分为两代：cur 记录当前时间桶内触发过的回调，prev 记录上一个桶，
+// 每个桶的长度为去重窗口，更早的记录随 prev 整体丢弃，不需要遍历。
+// 单代记录数达到 hookSeenLimit 时提前换代，内存占用不超过 2*hookSeenLimit 条；
+// 此时被提前丢弃的回调可能在窗口内再次触发
+type hookLimiter struct {
+	mu       sync.Mutex
+	cur      map[string]time.Time // 去重：回调标识 -> 上次触发时间
+	prev     map[string]time.Time
+	curStart time.Time // cur 对应时间桶的起点
+	tokens   float64   // 限流：令牌桶
+	last     time.Time
+
+	now func() time.Time // 测试时替换
+}
+
+// hookSeenLimit 去重表每一代最多记录的回调数
+const hookSeenLimit = 4096
+
+// allow 判断标识为 id 的回调本次是否触发。
+// 先检查限流：令牌耗尽时直接返回，不再查询或写入去重表
+func (h *hookLimiter) allow(cfg *Config, id string) bool {
+	window, rate := cfg.HookDedupWindow, cfg.HookRateLimit
+	if window <= 0 && rate <= 0 {
+		return true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	if h.now != nil {
+		now = h.now()
+	}
+
+	if rate > 0 {
+		if h.last.IsZero() {
+			h.tokens = float64(rate)
+		} else {
+			h.tokens += now.Sub(h.last).Seconds() * float64(rate)
+			if h.tokens > float64(rate) {
+				h.tokens = float64(rate)
+			}
+		}
+		h.last = now
+		if h.tokens < 1 {
+			return false
+		}
+	}
+	if window > 0 {
+		h.rotate(now, window)
+		if h.seenWithin(id, now, window) {
+			return false
+		}
+		h.cur[id] = now
+	}
+	if rate > 0 {
+		h.tokens--
+	}
+	return true
+}
+
+// rotate 在当前时间桶结束或 cur 写满时换代，调用方需持有 h.mu
+func (h *hookLimiter) rotate(now time.Time, window time.Duration) {
+	elapsed := now.Sub(h.curStart)
+	switch {
+	case h.cur == nil || elapsed >= 2*window:
+		// 上一个桶也已过期
+		h.prev = nil
+	case elapsed >= window || len(h.cur) >= hookSeenLimit:
+		h.prev = h.cur
+	default:
+		return
+	}
+	h.cur = make(map[string]time.Time)
+	h.curStart = now
+}
+
+// seenWithin 判断 id 在 window 内是否触发过，调用方需持有 h.mu
+func (h *hookLimiter) seenWithin(id string, now time.Time, window time.Duration) bool {
+	if t, ok := h.cur[id]; ok {
+		return now.Sub(t) < window
+	}
+	if t, ok := h.prev[id]; ok {
+		return now.Sub(t) < window
+	}
+	return false
+}
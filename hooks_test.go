@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLocale_Hooks(t *testing.T) {
+	var (
+		missing []string
+		render  []string
+	)
+	bundle := New(Config{
+		DefaultLang: "en",
+		OnMissingKey: func(langs []string, key string) {
+			missing = append(missing, key)
+		},
+		OnRenderError: func(lang, key string, err error) {
+			render = append(render, lang+":"+key)
+		},
+		HookDedupWindow: time.Minute,
+		HookRateLimit:   2,
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bundle.hooks.now = func() time.Time { return now }
+	bundle.RegisterMessages("en", map[string]string{"broken": "Hi {user.nick}"})
+	loc := bundle.Locale("en")
+
+	// 同一 key 在窗口内只回调一次
+	loc.T("a", nil)
+	loc.T("a", nil)
+	loc.T("broken", nil)
+	if !reflect.DeepEqual(missing, []string{"a"}) || !reflect.DeepEqual(render, []string{"en:broken"}) {
+		t.Fatalf("dedup: missing=%v render=%v", missing, render)
+	}
+
+	// 每秒最多 2 次
+	loc.T("b", nil)
+	if len(missing) != 1 {
+		t.Fatalf("rate limit: missing=%v", missing)
+	}
+	now = now.Add(time.Second)
+	loc.T("b", nil)
+	loc.T("c", nil)
+	if !reflect.DeepEqual(missing, []string{"a", "b", "c"}) {
+		t.Fatalf("after refill: missing=%v", missing)
+	}
+
+	// 窗口过后再次回调
+	now = now.Add(time.Minute)
+	loc.T("a", nil)
+	if len(missing) != 4 {
+		t.Fatalf("after window: missing=%v", missing)
+	}
+}
+
+func TestHookLimiter_Bounded(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := &hookLimiter{now: func() time.Time { return now }}
+	cfg := &Config{HookDedupWindow: time.Hour}
+	for i := 0; i < 5*hookSeenLimit; i++ {
+		if !h.allow(cfg, fmt.Sprint(i)) {
+			t.Fatalf("allow(%d) = false", i)
+		}
+	}
+	if n := len(h.cur) + len(h.prev); n > 2*hookSeenLimit {
+		t.Fatalf("dedup table holds %d entries", n)
+	}
+	// 最近的记录仍然去重
+	if h.allow(cfg, fmt.Sprint(5*hookSeenLimit-1)) {
+		t.Fatal("recent id should be deduplicated")
+	}
+
+	// 限流时不写入去重表
+	limited := &hookLimiter{now: func() time.Time { return now }}
+	cfg = &Config{HookDedupWindow: time.Hour, HookRateLimit: 1}
+	limited.allow(cfg, "a")
+	if limited.allow(cfg, "b") {
+		t.Fatal("rate limit exceeded")
+	}
+	if _, ok := limited.cur["b"]; ok {
+		t.Fatal("rate-limited id should not be recorded")
+	}
+	now = now.Add(time.Second)
+	if !limited.allow(cfg, "b") {
+		t.Fatal("b should fire once the bucket refills")
+	}
+}
+
+func TestLocale_Replace(t *testing.T) {
+	bundle := New(Config{DefaultLang: "en"})
+	bundle.RegisterMessages("en", map[string]string{"greeting": "Hello {name}"})
+	bundle.RegisterMessages("de", map[string]string{"greeting": "Hallo {user.nick}"})
+	args := map[string]any{"name": "Ann"}
+
+	cases := []struct {
+		name    string
+		replace ReplaceFunc
+		lang    string
+		key     string
+		want    string
+	}{
+		{"Default_Missing", nil, "de", "user.login_success", "user.login_success"},
+		{"Default_Render", nil, "de", "greeting", "Hallo {user.nick}"},
+		{"Key", ReplaceKey, "de", "greeting", "greeting"},
+		{"Humanized", ReplaceHumanizedKey, "de", "billing:user.login_success", "Login success"},
+		{"Marker", ReplaceMarker("⟦", "⟧"), "de", "missing", "⟦missing⟧"},
+		{"DefaultLang", ReplaceDefaultLang, "de", "greeting", "Hello Ann"},
+		{"DefaultLang_Missing", ReplaceDefaultLang, "de", "missing", "missing"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := mustClone(t, bundle)
+			b.config.Replace = c.replace
+			if got := b.Locale(c.lang).T(c.key, args); got != c.want {
+				t.Fatalf("T(%q) = %q, want %q", c.key, got, c.want)
+			}
+		})
+	}
+}
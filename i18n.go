@@ -1,35 +1,176 @@
 package i18n
 
-// MessageStore lang -> key -> message
-type MessageStore map[string]map[string]string
+import (
+	"fmt"
+	"strings"
+)
+
+// NamespaceSeparator 分隔命名空间与 key，例如 "billing:invoice.title"
+const NamespaceSeparator = ":"
+
+// NamespacedKey 拼接命名空间与 key，ns 为空时原样返回 key
+func NamespacedKey(ns, key string) string {
+	if ns == "" {
+		return key
+	}
+	return ns + NamespaceSeparator + key
+}
+
+// SplitNamespace 把 "billing:invoice.title" 拆分为 ("billing", "invoice.title")，
+// 没有命名空间时 ns 为空
+func SplitNamespace(key string) (ns, rest string) {
+	if i := strings.Index(key, NamespaceSeparator); i >= 0 {
+		return key[:i], key[i+len(NamespaceSeparator):]
+	}
+	return "", key
+}
 
 // Locale 是绑定了“语言链”的翻译入口
 type Locale struct {
-	bundle *Bundle
-	langs  []string // lang fallback chain
+	bundle    *Bundle
+	overlay   *Bundle  // 覆盖层（见 Bundle.Overlay），为 nil 表示没有
+	langs     []string // lang fallback chain
+	namespace string   // default namespace
+}
+
+// WithNamespace 返回绑定了默认命名空间的 Locale 副本：
+// 不带命名空间的 key 先在 ns 中查找，找不到再查全局命名空间
+func (l *Locale) WithNamespace(ns string) *Locale {
+	cp := *l
+	cp.namespace = ns
+	return &cp
 }
 
 // T 翻译函数：T("user.login.success", map[string]any{"name": "Tom"})
+// 也可以显式指定命名空间：T("billing:invoice.title", args)
+//
+// T 不返回错误：找不到翻译时返回 key，模板渲染失败时返回原文；需要区分时使用 Translate。
+// 翻译失败时会触发 Config.OnMissingKey / Config.OnRenderError，返回的文本可以通过 Config.Replace 定制
 func (l *Locale) T(key string, args map[string]any) string {
+	text, err := l.Translate(key, args)
+	if err != nil {
+		return l.fail(key, args, text, err)
+	}
+	return text
+}
+
+// Translate 与 T 相同，但同时返回错误：
+// 找不到翻译时返回 key 与 *MissingKeyError（errors.Is(err, ErrMissingKey) 为 true），
+// 模板渲染失败时返回原文与 *RenderError。
+//
+// 开启 Config.RenderFallthrough 后，渲染失败会继续尝试语言链中下一个有该 key 的语言
+// （只有渲染失败后才查询后续语言）；
+// 后续语言渲染成功时返回该结果，同时仍返回 *RenderError，其中 FallbackLang 为实际使用的语言。
+//
+// 读取 Store 失败时返回 key 与该错误。
+//
+// Translate 不触发回调也不使用 Config.Replace，错误由调用方处理
+func (l *Locale) Translate(key string, args map[string]any) (string, error) {
+	c := l.candidates(key)
+	tr, ok, err := c.next()
+	if err != nil {
+		return key, err
+	}
+	if !ok {
+		return key, &MissingKeyError{Key: key, Langs: l.langs}
+	}
+	var (
+		raw       string
+		renderErr *RenderError
+	)
+	for {
+		// 使用自定义模板引擎替换 {name} 等占位符
+		res, err := l.bundle.render(tr.text, args)
+		if err == nil {
+			if renderErr != nil {
+				renderErr.FallbackLang = tr.lang
+				return res, renderErr
+			}
+			return res, nil
+		}
+		if renderErr == nil {
+			raw, renderErr = tr.text, &RenderError{Key: key, Lang: tr.lang, Err: err}
+		}
+		if !c.all {
+			return raw, renderErr
+		}
+		// 渲染失败后才查找下一个候选
+		if tr, ok, err = c.next(); err != nil {
+			return key, err
+		}
+		if !ok {
+			return raw, renderErr
+		}
+	}
+}
+
+// translation 语言链中某个语言的翻译
+type translation struct {
+	text string
+	lang string
+}
+
+// candidates 沿语言链依次查找 key 的翻译，每次 next 只查询到下一个候选为止；
+// 绑定了默认命名空间时，不带命名空间的 key 先在默认命名空间中查找
+type candidates struct {
+	l           *Locale
+	keys        []string
+	base, layer Store // layer 为覆盖层，可以为 nil
+	all         bool  // Config.RenderFallthrough：渲染失败时继续尝试下一个候选
+	ki, li      int   // 下一次查找的 key 与语言下标
+}
+
+// candidates 返回 key 的候选翻译；懒加载模式下先加载语言链中尚未加载的语言
+func (l *Locale) candidates(key string) *candidates {
+	c := &candidates{l: l}
 	if l.bundle == nil {
-		return key
+		return c
+	}
+	if idx := l.bundle.lazy.Load(); idx != nil {
+		// 长期持有的 Locale 也需要刷新使用时间，语言被卸载后在这里重新加载
+		idx.ensure(l.bundle, l.langs)
 	}
+	// 只在锁内取出当前的 Store，查询 Store 时不持有任何锁
 	l.bundle.mu.RLock()
-	defer l.bundle.mu.RUnlock()
-
-	for _, lang := range l.langs {
-		if msgs, ok := l.bundle.messages[lang]; ok {
-			if text, ok2 := msgs[key]; ok2 {
-				// 使用自定义模板引擎替换 {name} 等占位符
-				res, err := RenderTemplate(text, args)
-				if err != nil {
-					// 模板解析失败时，退化为原文
-					return text
-				}
-				return res
+	c.base, c.all = l.bundle.store, l.bundle.config.RenderFallthrough
+	l.bundle.mu.RUnlock()
+	if l.overlay != nil {
+		l.overlay.mu.RLock()
+		c.layer = l.overlay.store
+		l.overlay.mu.RUnlock()
+	}
+	if l.namespace != "" && !strings.Contains(key, NamespaceSeparator) {
+		c.keys = append(c.keys, NamespacedKey(l.namespace, key))
+	}
+	c.keys = append(c.keys, key)
+	return c
+}
+
+// next 返回下一个候选翻译，每个语言先查覆盖层再查 base；没有更多候选时返回 false
+func (c *candidates) next() (translation, bool, error) {
+	for ; c.ki < len(c.keys); c.ki, c.li = c.ki+1, 0 {
+		key := c.keys[c.ki]
+		for c.li < len(c.l.langs) {
+			lang := c.l.langs[c.li]
+			c.li++
+			var (
+				text string
+				ok   bool
+				err  error
+			)
+			if c.layer != nil {
+				text, ok, err = c.layer.Get(lang, key)
+			}
+			if err == nil && !ok {
+				text, ok, err = c.base.Get(lang, key)
+			}
+			if err != nil {
+				return translation{}, false, fmt.Errorf("i18n: store %s %s: %w", lang, key, err)
+			}
+			if ok {
+				return translation{text: text, lang: lang}, true, nil
 			}
 		}
 	}
-	// 找不到翻译时，直接返回 key（或者返回 key + 提示）
-	return key
+	return translation{}, false, nil
 }
@@ -0,0 +1,153 @@
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// resolveIncludes 把 f.Include 中的文件合并进 f：被 include 的翻译先注册，f 自身的 key 优先。
+// 片段文件可以继续 include 其他文件；stack 为当前的 include 路径，用于检测循环
+func resolveIncludes(fsys fs.FS, f *LocaleFile, stack []string, included map[string]struct{}) error {
+	if len(f.Include) == 0 {
+		return nil
+	}
+	msgs := make(map[string]string)
+	meta := make(map[string]MessageMeta)
+	sources := make(map[string]Source)
+
+	for _, inc := range f.Include {
+		p := path.Join(path.Dir(f.Path), inc)
+		for _, s := range stack {
+			if s == p {
+				return fmt.Errorf("include cycle: %s -> %s", strings.Join(stack, " -> "), p)
+			}
+		}
+		child, err := openLocaleFile(fsys, p)
+		if err != nil {
+			return fmt.Errorf("include %s: %w", inc, err)
+		}
+		if err := resolveIncludes(fsys, child, append(stack, p), included); err != nil {
+			return err
+		}
+		if included != nil {
+			included[p] = struct{}{}
+		}
+		for k, v := range child.Messages {
+			msgs[k] = v
+			if src, ok := child.Sources[k]; ok {
+				sources[k] = src
+			} else {
+				sources[k] = Source{File: child.Path}
+			}
+			if m, ok := child.Meta[k]; ok {
+				meta[k] = m
+			} else {
+				delete(meta, k)
+			}
+		}
+	}
+
+	for k, v := range f.Messages {
+		msgs[k] = v
+		if src, ok := f.Sources[k]; ok {
+			sources[k] = src
+		} else {
+			delete(sources, k)
+		}
+		if m, ok := f.Meta[k]; ok {
+			meta[k] = m
+		} else {
+			delete(meta, k)
+		}
+	}
+	f.Messages, f.Meta, f.Sources = msgs, meta, sources
+	return nil
+}
+
+// resolveExtends 展开语言之间的 extends：子语言继承父语言（以及更上层祖先）所有命名空间中
+// 自身没有定义的 key。返回的 LocaleFile 以子语言为 Language，InheritedFrom 为提供翻译的祖先语言，
+// Path/Sources 仍指向祖先的文件。检测未知父语言、同一语言声明了不同父语言以及循环继承
+func resolveExtends(files []*LocaleFile) ([]*LocaleFile, error) {
+	byLang := make(map[string][]*LocaleFile)
+	parent := make(map[string]string)
+	for _, f := range files {
+		byLang[f.Language] = append(byLang[f.Language], f)
+		if f.Extends == "" {
+			continue
+		}
+		if p, ok := parent[f.Language]; ok && p != f.Extends {
+			return nil, fmt.Errorf("load %s: language %s extends both %s and %s", f.Path, f.Language, p, f.Extends)
+		}
+		parent[f.Language] = f.Extends
+	}
+
+	langs := make([]string, 0, len(parent))
+	for lang, p := range parent {
+		if _, ok := byLang[p]; !ok {
+			return nil, fmt.Errorf("language %s extends unknown language %s", lang, p)
+		}
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	var inherited []*LocaleFile
+	for _, lang := range langs {
+		// 检测循环：沿父链向上，重复出现即为循环
+		chain := []string{lang}
+		for p := parent[lang]; p != ""; p = parent[p] {
+			for _, c := range chain {
+				if c == p {
+					return nil, fmt.Errorf("extends cycle: %s -> %s", strings.Join(chain, " -> "), p)
+				}
+			}
+			chain = append(chain, p)
+		}
+
+		defined := make(map[string]struct{})
+		for _, f := range byLang[lang] {
+			for k := range f.Messages {
+				defined[NamespacedKey(f.Namespace, k)] = struct{}{}
+			}
+		}
+		// 由近及远：越近的祖先优先
+		for _, anc := range chain[1:] {
+			for _, f := range byLang[anc] {
+				cp := &LocaleFile{
+					Path:          f.Path,
+					Language:      lang,
+					Namespace:     f.Namespace,
+					Messages:      make(map[string]string),
+					InheritedFrom: anc,
+					origin:        f.origin,
+				}
+				for k, v := range f.Messages {
+					key := NamespacedKey(f.Namespace, k)
+					if _, ok := defined[key]; ok {
+						continue
+					}
+					defined[key] = struct{}{}
+					cp.Messages[k] = v
+					if src, ok := f.Sources[k]; ok {
+						if cp.Sources == nil {
+							cp.Sources = make(map[string]Source)
+						}
+						cp.Sources[k] = src
+					}
+					if m, ok := f.Meta[k]; ok {
+						if cp.Meta == nil {
+							cp.Meta = make(map[string]MessageMeta)
+						}
+						cp.Meta[k] = m
+					}
+				}
+				if len(cp.Messages) > 0 {
+					inherited = append(inherited, cp)
+				}
+			}
+		}
+	}
+	return inherited, nil
+}
@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundle_Extends(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.yaml":     {Data: []byte("language: en\nmessages:\n  hello: Hello\n  only.en: en\n")},
+		"zh-CN.yaml":  {Data: []byte("language: zh-CN\nmessages:\n  hello: 你好\n  bye: 再见\n  color: 颜色\n")},
+		"zh-TW.yaml":  {Data: []byte("language: zh-TW\nextends: zh-CN\nmessages:\n  color: 顏色\n")},
+		"zh-HK.yaml":  {Data: []byte("language: zh-HK\nextends: zh-TW\nmessages:\n  bye: 拜拜\n")},
+		"shop/tw.yml": {Data: []byte("language: zh-TW\nnamespace: shop\nmessages:\n  cart: 購物車\n")},
+	}
+
+	t.Run("Extends_Eager", func(t *testing.T) {
+		bundle := New(Config{ConflictPolicy: ConflictError})
+		if err := bundle.LoadFS(fsys, "."); err != nil {
+			t.Fatalf("LoadFS: %v", err)
+		}
+		checkExtends(t, bundle)
+		src, _ := bundle.Source("zh-HK", "hello")
+		if src.File != "zh-CN.yaml" {
+			t.Fatalf("inherited key should point at the parent file, got %v", src)
+		}
+	})
+	t.Run("Extends_Lazy", func(t *testing.T) {
+		bundle := New(Config{ConflictPolicy: ConflictError})
+		if err := bundle.LoadLazyFS(fsys, ".", LazyOptions{
+			OnError: func(lang string, err error) { t.Errorf("lazy %s: %v", lang, err) },
+		}); err != nil {
+			t.Fatalf("LoadLazyFS: %v", err)
+		}
+		checkExtends(t, bundle)
+	})
+	t.Run("Extends_ChildLoadedLater", func(t *testing.T) {
+		later := fstest.MapFS{"tw/zh-TW.yaml": {Data: []byte("language: zh-TW\nmessages:\n  bye: TB\n")}}
+		for _, policy := range []ConflictPolicy{ConflictLastWins, ConflictFirstWins, ConflictWarn, ConflictError} {
+			var conflicts []Conflict
+			bundle := New(Config{ConflictPolicy: policy, OnConflict: func(c Conflict) { conflicts = append(conflicts, c) }})
+			if err := bundle.LoadFS(fsys, "."); err != nil {
+				t.Fatalf("policy %d: LoadFS: %v", policy, err)
+			}
+			// 显式定义替换继承而来的翻译，在任何策略下都不算冲突
+			if err := bundle.LoadFS(later, "."); err != nil {
+				t.Fatalf("policy %d: LoadFS child: %v", policy, err)
+			}
+			if got := bundle.Locale("zh-TW").T("bye", nil); got != "TB" {
+				t.Fatalf("policy %d: T = %q, want TB", policy, got)
+			}
+			if len(conflicts) != 0 {
+				t.Fatalf("policy %d: unexpected conflicts %v", policy, conflicts)
+			}
+			if src, _ := bundle.Source("zh-TW", "bye"); src.File != "tw/zh-TW.yaml" {
+				t.Fatalf("policy %d: source = %v", policy, src)
+			}
+		}
+	})
+}
+
+func checkExtends(t *testing.T, bundle *Bundle) {
+	t.Helper()
+	cases := []struct{ lang, key, want string }{
+		{"zh-TW", "color", "顏色"},
+		{"zh-TW", "hello", "你好"},
+		{"zh-TW", "shop:cart", "購物車"},
+		{"zh-HK", "bye", "拜拜"},
+		{"zh-HK", "color", "顏色"},
+		{"zh-HK", "hello", "你好"},
+		{"zh-HK", "shop:cart", "購物車"},
+		{"zh-HK", "only.en", "en"}, // 运行时 fallback 仍然生效
+		{"zh-CN", "color", "颜色"},
+	}
+	for _, c := range cases {
+		if got := bundle.Locale(c.lang).T(c.key, nil); got != c.want {
+			t.Errorf("Locale(%q).T(%q) = %q, want %q", c.lang, c.key, got, c.want)
+		}
+	}
+}
+
+func TestBundle_Include(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common/brand.yaml": {Data: []byte("messages:\n  brand: ACME\n  title: Base\n")},
+		"en/app.yaml":       {Data: []byte("language: en\ninclude: [../common/brand.yaml]\nmessages:\n  title: App\n")},
+	}
+	bundle := New(Config{})
+	if err := bundle.LoadFS(fsys, "en"); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	loc := bundle.Locale("en")
+	if got := loc.T("brand", nil); got != "ACME" {
+		t.Fatalf("T: %q", got)
+	}
+	if got := loc.T("title", nil); got != "App" {
+		t.Fatalf("own key should win over included key, got %q", got)
+	}
+	if src, _ := bundle.Source("en", "brand"); src.File != "common/brand.yaml" || src.Line != 2 {
+		t.Fatalf("Source: %v", src)
+	}
+
+	// 整个目录一起加载时，被 include 的片段文件不需要 language
+	bundle = New(Config{})
+	if err := bundle.LoadFS(fsys, "."); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+}
+
+func TestReadLocaleFS_InheritErrors(t *testing.T) {
+	cases := map[string]struct {
+		fsys fstest.MapFS
+		want string
+	}{
+		"extends_cycle": {fstest.MapFS{
+			"a.yaml": {Data: []byte("language: a\nextends: b\nmessages:\n  k: a\n")},
+			"b.yaml": {Data: []byte("language: b\nextends: a\nmessages:\n  k: b\n")},
+		}, "extends cycle"},
+		"extends_unknown": {fstest.MapFS{
+			"a.yaml": {Data: []byte("language: a\nextends: zz\nmessages:\n  k: a\n")},
+		}, "unknown language"},
+		"include_cycle": {fstest.MapFS{
+			"a.yaml": {Data: []byte("language: a\ninclude: [b.yaml]\nmessages:\n  k: a\n")},
+			"b.yaml": {Data: []byte("include: [a.yaml]\nmessages:\n  k: b\n")},
+		}, "include cycle"},
+		"orphan_fragment": {fstest.MapFS{
+			"a.yaml": {Data: []byte("language: a\nmessages:\n  k: a\n")},
+			"b.yaml": {Data: []byte("messages:\n  k: b\n")},
+		}, "missing 'language'"},
+	}
+	for name, c := range cases {
+		_, err := ReadLocaleFS(c.fsys, ".")
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Fatalf("%s: err = %v, want %q", name, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LazyOptions 懒加载模式的配置
+type LazyOptions struct {
+	// IdleTTL 大于 0 时，超过该时长没有被使用的语言会被卸载，下次使用时重新加载
+	IdleTTL time.Duration
+
+	// OnError 懒加载或卸载失败时回调；加载失败的语言保持未加载状态，下次使用时重试
+	OnError func(lang string, err error)
+}
+
+// lazyFile 索引中的一个翻译文件
+type lazyFile struct {
+	fsys   fs.FS
+	origin any
+	path   string
+}
+
+// lazyEntry 某个语言的懒加载状态
+type lazyEntry struct {
+	mu       sync.Mutex // 保证同一语言只被加载一次
+	files    []lazyFile
+	parent   string            // extends 声明的父语言
+	keys     map[string]Source // 懒加载注册的 key 及其来源，卸载时只删除这些 key
+	reload   bool              // 已经加载过，再次加载时只补回仍属于懒加载文件的 key
+	loaded   atomic.Bool
+	lastUsed atomic.Int64 // unix nano
+}
+
+// lazyIndex 按语言索引翻译文件，语言第一次被使用时才加载
+type lazyIndex struct {
+	mu        sync.RWMutex
+	entries   map[string]*lazyEntry
+	opts      LazyOptions
+	lastSweep atomic.Int64
+
+	now func() time.Time
+}
+
+// LoadLazyDir 以懒加载模式索引 dir 目录，等同于 LoadLazyFS(os.DirFS(dir), ".", opts)
+func (b *Bundle) LoadLazyDir(dir string, opts LazyOptions) error {
+	if err := b.LoadLazyFS(os.DirFS(dir), ".", opts); err != nil {
+		return fmt.Errorf("%s: %w", dir, err)
+	}
+	return nil
+}
+
+// LoadLazyFS 以懒加载模式索引 fsys 中 root 目录下的翻译文件：
+// 这里只按语言建立索引，不保留翻译内容；某个语言（及其 fallback 语言）
+// 第一次被 Bundle.Locale 使用时才真正加载。并发的首次访问只会加载一次。
+//
+// 多次调用会合并索引，opts 以最后一次调用为准。
+func (b *Bundle) LoadLazyFS(fsys fs.FS, root string, opts LazyOptions) error {
+	// 建立索引与真正加载时都会校验签名
+	origin := fsOrigin(fsys)
+	fsys = b.verifiedFS(fsys)
+	headers, err := readLocaleHeaders(fsys, root)
+	if err != nil {
+		return err
+	}
+
+	idx := b.lazy.Load()
+	if idx == nil {
+		idx = &lazyIndex{
+			entries: make(map[string]*lazyEntry),
+			now:     time.Now,
+		}
+		if !b.lazy.CompareAndSwap(nil, idx) {
+			idx = b.lazy.Load()
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.opts = opts
+	for _, h := range headers {
+		e := idx.entries[h.Language]
+		if e == nil {
+			e = &lazyEntry{}
+			idx.entries[h.Language] = e
+		}
+		e.mu.Lock()
+		e.files = append(e.files, lazyFile{fsys: fsys, origin: origin, path: h.Path})
+		if h.Extends != "" {
+			e.parent = h.Extends
+		}
+		// 已加载的语言有了新文件，下次使用时重新加载；
+		// 重新加载只补回仍属于懒加载文件的 key，之后注册或覆盖的翻译不受影响
+		e.loaded.Store(false)
+		e.mu.Unlock()
+	}
+	return nil
+}
+
+// readLocaleHeaders 只读取 root 目录下每个文件的文件头（见 HeaderLoader），
+// 与 ReadLocaleFS 做相同的 include 片段与 extends 校验，但不解析翻译内容
+func readLocaleHeaders(fsys fs.FS, root string) ([]*LocaleFile, error) {
+	var headers []*LocaleFile
+	included := make(map[string]struct{})
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isLocaleFile(p) {
+			return nil
+		}
+		h, err := readLocaleHeader(fsys, p)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", p, err)
+		}
+		for _, inc := range h.Include {
+			included[path.Join(path.Dir(p), inc)] = struct{}{}
+		}
+		headers = append(headers, h)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	own := headers[:0]
+	for _, h := range headers {
+		if h.Language != "" {
+			own = append(own, h)
+			continue
+		}
+		if _, ok := included[h.Path]; !ok {
+			return nil, fmt.Errorf("load %s: file %s missing 'language' field", h.Path, h.Path)
+		}
+	}
+	// 文件头没有翻译内容，这里只用来检查未知父语言与循环继承
+	if _, err := resolveExtends(own); err != nil {
+		return nil, err
+	}
+	return own, nil
+}
+
+// readLocaleHeader 读取单个文件的文件头；Loader 没有实现 HeaderLoader，
+// 或者文件头中没有找到 language（例如 key 带引号）时退回完整解析
+func readLocaleHeader(fsys fs.FS, name string) (*LocaleFile, error) {
+	loader, ok := lookupLoader(path.Ext(name))
+	if !ok {
+		return nil, fmt.Errorf("no loader registered for %q", path.Ext(name))
+	}
+	fh, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(fh)
+	fh.Close()
+	if err != nil {
+		return nil, err
+	}
+	var h *LocaleFile
+	if hl, ok := loader.(HeaderLoader); ok {
+		if h, err = hl.LoadHeader(data); err != nil {
+			return nil, err
+		}
+	}
+	if h == nil || h.Language == "" {
+		if h, err = loader.Load(data); err != nil {
+			return nil, err
+		}
+	}
+	h.Messages, h.Meta, h.Sources = nil, nil, nil
+	h.Path = name
+	return h, nil
+}
+
+// Preload 立即加载若干语言（不含 fallback），返回第一个加载错误
+// 非懒加载模式下什么也不做
+func (b *Bundle) Preload(langs ...string) error {
+	idx := b.lazy.Load()
+	if idx == nil {
+		return nil
+	}
+	for _, lang := range langs {
+		if err := idx.load(b, lang); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EvictIdle 卸载超过 LazyOptions.IdleTTL 没有被使用的语言
+// 通常不需要手动调用：Bundle.Locale 会定期顺带清理
+func (b *Bundle) EvictIdle() {
+	if idx := b.lazy.Load(); idx != nil {
+		idx.sweep(b, idx.now())
+	}
+}
+
+// ensure 加载语言链中尚未加载的语言，加载失败时通过 OnError 回调
+func (idx *lazyIndex) ensure(b *Bundle, langs []string) {
+	for _, lang := range langs {
+		if err := idx.load(b, lang); err != nil {
+			idx.mu.RLock()
+			onError := idx.opts.OnError
+			idx.mu.RUnlock()
+			if onError != nil {
+				onError(lang, err)
+			}
+		}
+	}
+
+	idx.mu.RLock()
+	ttl := idx.opts.IdleTTL
+	idx.mu.RUnlock()
+	if ttl <= 0 {
+		return
+	}
+	now := idx.now()
+	last := idx.lastSweep.Load()
+	if now.UnixNano()-last >= int64(ttl/2) && idx.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		idx.sweep(b, now)
+	}
+}
+
+// load 加载单个语言并刷新最近使用时间
+func (idx *lazyIndex) load(b *Bundle, lang string) error {
+	idx.mu.RLock()
+	e := idx.entries[lang]
+	idx.mu.RUnlock()
+	if e == nil {
+		return nil
+	}
+	e.lastUsed.Store(idx.now().UnixNano())
+	if e.loaded.Load() {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.loaded.Load() {
+		return nil
+	}
+	files, err := idx.read(lang)
+	if err != nil {
+		return err
+	}
+	var filter func([]*LocaleFile) ([]*LocaleFile, error)
+	if e.reload {
+		filter = func(all []*LocaleFile) ([]*LocaleFile, error) {
+			files, err = b.lazyOwnedLocked(lang, all)
+			return files, err
+		}
+	}
+	if err := b.registerFilesWith(files, filter); err != nil {
+		return err
+	}
+	keys := make(map[string]Source)
+	for _, f := range files {
+		for k := range f.Messages {
+			keys[NamespacedKey(f.Namespace, k)] = f.source(k)
+		}
+	}
+	e.keys = keys
+	e.reload = true
+	e.loaded.Store(true)
+	return nil
+}
+
+// lazyOwnedLocked 过滤重新加载的懒加载文件，只保留仍属于这批文件的 key：
+// 当前没有翻译，或者当前的定义位置就在这批文件（含 include 片段与祖先文件）中。
+// 卸载之后通过 RegisterMessages、SQL 或其他文件注册的翻译保留。调用方需持有 b.wmu
+func (b *Bundle) lazyOwnedLocked(lang string, files []*LocaleFile) ([]*LocaleFile, error) {
+	type owner struct {
+		file   string
+		origin any
+	}
+	owners := make(map[owner]struct{})
+	for _, f := range files {
+		for k := range f.Messages {
+			src := f.source(k)
+			owners[owner{src.File, src.origin}] = struct{}{}
+		}
+	}
+
+	// 先在锁内按定义位置判断，没有定义位置的 key 再到 Store 中确认是否存在
+	owned := make(map[string]bool)
+	b.mu.RLock()
+	for _, f := range files {
+		for k := range f.Messages {
+			key := NamespacedKey(f.Namespace, k)
+			if cur, ok := b.sources[lang][key]; ok {
+				_, mine := owners[owner{cur.File, cur.origin}]
+				owned[key] = mine
+			}
+		}
+	}
+	b.mu.RUnlock()
+
+	kept := make([]*LocaleFile, 0, len(files))
+	for _, f := range files {
+		cp := *f
+		cp.Messages = make(map[string]string, len(f.Messages))
+		for k, v := range f.Messages {
+			key := NamespacedKey(f.Namespace, k)
+			mine, known := owned[key]
+			if !known {
+				_, exists, err := b.store.Get(lang, key)
+				if err != nil {
+					return nil, fmt.Errorf("i18n: store %s %s: %w", lang, key, err)
+				}
+				mine = !exists
+			}
+			if mine {
+				cp.Messages[k] = v
+			}
+		}
+		kept = append(kept, &cp)
+	}
+	return kept, nil
+}
+
+// read 读取 lang 自身的文件，以及沿 extends 链向上的祖先文件，返回 lang 的文件与继承而来的翻译
+func (idx *lazyIndex) read(lang string) ([]*LocaleFile, error) {
+	idx.mu.RLock()
+	var chain []lazyFile
+	seen := make(map[string]bool)
+	for l := lang; l != "" && !seen[l]; {
+		seen[l] = true
+		e := idx.entries[l]
+		if e == nil {
+			break
+		}
+		chain = append(chain, e.files...)
+		l = e.parent
+	}
+	idx.mu.RUnlock()
+
+	all := make([]*LocaleFile, 0, len(chain))
+	for _, lf := range chain {
+		f, err := readLocaleFile(lf.fsys, lf.path)
+		if err != nil {
+			return nil, fmt.Errorf("load %s: %w", lf.path, err)
+		}
+		f.origin = lf.origin
+		all = append(all, f)
+	}
+	inherited, err := resolveExtends(all)
+	if err != nil {
+		return nil, err
+	}
+	var files []*LocaleFile
+	for _, f := range append(all, inherited...) {
+		if f.Language == lang {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// sweep 卸载空闲的语言中懒加载的翻译
+func (idx *lazyIndex) sweep(b *Bundle, now time.Time) {
+	idx.mu.RLock()
+	ttl, onError := idx.opts.IdleTTL, idx.opts.OnError
+	entries := make(map[string]*lazyEntry, len(idx.entries))
+	for lang, e := range idx.entries {
+		entries[lang] = e
+	}
+	idx.mu.RUnlock()
+	if ttl <= 0 {
+		return
+	}
+
+	deadline := now.Add(-ttl).UnixNano()
+	for lang, e := range entries {
+		if !e.loaded.Load() || e.lastUsed.Load() > deadline {
+			continue
+		}
+		e.mu.Lock()
+		// 加锁后再确认一次，避免卸载刚刚被使用的语言
+		if e.loaded.Load() && e.lastUsed.Load() <= deadline {
+			e.loaded.Store(false)
+			if err := b.evictLazy(lang, e.keys); err != nil && onError != nil {
+				onError(lang, err)
+			}
+			e.keys = nil
+		}
+		e.mu.Unlock()
+	}
+}
+
+// evictLazy 删除懒加载注册的 key；已经被 RegisterMessages、SQL 或其他文件覆盖的 key 保留
+func (b *Bundle) evictLazy(lang string, keys map[string]Source) error {
+	b.wmu.Lock()
+	defer b.wmu.Unlock()
+	var evict []string
+	for k, src := range keys {
+		if cur, ok := b.sources[lang][k]; ok && cur == src {
+			evict = append(evict, k)
+		}
+	}
+	return b.deleteLocked(lang, evict)
+}
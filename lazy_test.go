@@ -0,0 +1,216 @@
+package i18n
+
+import (
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// countingFS 记录每个文件被打开的次数
+type countingFS struct {
+	fstest.MapFS
+	mu    sync.Mutex
+	opens map[string]int
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	c.mu.Lock()
+	c.opens[name]++
+	c.mu.Unlock()
+	return c.MapFS.Open(name)
+}
+
+func (c *countingFS) count(name string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.opens[name]
+}
+
+func newCountingFS() *countingFS {
+	return &countingFS{
+		MapFS: fstest.MapFS{
+			"en.yaml": {Data: []byte("language: en\nmessages:\n  hello: Hello\n  bye: Bye\n")},
+			"fr.yaml": {Data: []byte("language: fr\nmessages:\n  hello: Bonjour\n")},
+			"de.yaml": {Data: []byte("language: de\nmessages:\n  hello: Hallo\n")},
+		},
+		opens: make(map[string]int),
+	}
+}
+
+func TestBundle_LoadLazyFS(t *testing.T) {
+	fsys := newCountingFS()
+	bundle := New(Config{})
+	if err := bundle.LoadLazyFS(fsys, ".", LazyOptions{}); err != nil {
+		t.Fatalf("LoadLazyFS: %v", err)
+	}
+	if langs, _ := bundle.store.Languages(); len(langs) != 0 {
+		t.Fatalf("nothing should be loaded after indexing, got %v", langs)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loc := bundle.Locale("fr")
+			if got := loc.T("hello", nil); got != "Bonjour" {
+				t.Errorf("T: %q", got)
+			}
+			// fallback 语言 en 也应被加载
+			if got := loc.T("bye", nil); got != "Bye" {
+				t.Errorf("T: %q", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 一次用于建立索引，一次用于首次加载
+	if n := fsys.count("fr.yaml"); n != 2 {
+		t.Fatalf("fr.yaml opened %d times, want 2", n)
+	}
+	if n := fsys.count("de.yaml"); n != 1 {
+		t.Fatalf("de.yaml opened %d times, want 1", n)
+	}
+}
+
+func TestBundle_LoadLazyFS_IdleEviction(t *testing.T) {
+	fsys := newCountingFS()
+	bundle := New(Config{})
+	if err := bundle.LoadLazyFS(fsys, ".", LazyOptions{IdleTTL: time.Minute}); err != nil {
+		t.Fatalf("LoadLazyFS: %v", err)
+	}
+	var clock atomic.Int64
+	clock.Store(time.Now().UnixNano())
+	idx := bundle.lazy.Load()
+	idx.now = func() time.Time { return time.Unix(0, clock.Load()) }
+
+	if got := bundle.Locale("de").T("hello", nil); got != "Hallo" {
+		t.Fatalf("T: %q", got)
+	}
+
+	clock.Add(int64(2 * time.Minute))
+	bundle.Locale("en")
+
+	if keys, _ := bundle.store.Keys("de"); len(keys) > 0 {
+		t.Fatal("idle language de should be evicted")
+	}
+
+	if got := bundle.Locale("de").T("hello", nil); got != "Hallo" {
+		t.Fatalf("T after eviction: %q", got)
+	}
+	if n := fsys.count("de.yaml"); n != 3 {
+		t.Fatalf("de.yaml opened %d times, want 3", n)
+	}
+}
+
+func TestBundle_LoadLazyFS_EvictKeepsManualMessages(t *testing.T) {
+	fsys := newCountingFS()
+	bundle := New(Config{})
+	if err := bundle.LoadLazyFS(fsys, ".", LazyOptions{IdleTTL: time.Minute}); err != nil {
+		t.Fatalf("LoadLazyFS: %v", err)
+	}
+	var clock atomic.Int64
+	clock.Store(time.Now().UnixNano())
+	bundle.lazy.Load().now = func() time.Time { return time.Unix(0, clock.Load()) }
+
+	if err := bundle.Preload("de"); err != nil {
+		t.Fatalf("Preload: %v", err)
+	}
+	bundle.RegisterMessages("de", map[string]string{"manual": "Manuell", "hello": "Servus"})
+
+	clock.Add(int64(2 * time.Minute))
+	bundle.EvictIdle()
+
+	if got, _, _ := bundle.store.Get("de", "manual"); got != "Manuell" {
+		t.Fatalf("manually registered key should survive eviction, got %q", got)
+	}
+	// 被手动覆盖的 key 同样保留
+	if got, _, _ := bundle.store.Get("de", "hello"); got != "Servus" {
+		t.Fatalf("overridden key should survive eviction, got %q", got)
+	}
+
+	// 重新加载只补回懒加载文件仍拥有的 key
+	if got := bundle.Locale("de").T("hello", nil); got != "Servus" {
+		t.Fatalf("T after reload: %q", got)
+	}
+	if got := bundle.Locale("de").T("manual", nil); got != "Manuell" {
+		t.Fatalf("T after reload: %q", got)
+	}
+	if n := fsys.count("de.yaml"); n != 3 {
+		t.Fatalf("de.yaml opened %d times, want 3", n)
+	}
+}
+
+func TestBundle_LoadLazyFS_ReloadKeepsLaterFiles(t *testing.T) {
+	lazy := fstest.MapFS{"en.yaml": {Data: []byte("language: en\nmessages:\n  t: A\n  u: A\n")}}
+	eager := fstest.MapFS{"en.yaml": {Data: []byte("language: en\nmessages:\n  t: B\n")}}
+	bundle := New(Config{})
+	if err := bundle.LoadLazyFS(lazy, ".", LazyOptions{IdleTTL: time.Minute}); err != nil {
+		t.Fatalf("LoadLazyFS: %v", err)
+	}
+	var clock atomic.Int64
+	clock.Store(time.Now().UnixNano())
+	bundle.lazy.Load().now = func() time.Time { return time.Unix(0, clock.Load()) }
+
+	if err := bundle.Preload("en"); err != nil {
+		t.Fatalf("Preload: %v", err)
+	}
+	if err := bundle.LoadFS(eager, "."); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+
+	// 卸载后重新加载
+	clock.Add(int64(2 * time.Minute))
+	bundle.EvictIdle()
+	loc := bundle.Locale("en")
+	if got := loc.T("t", nil); got != "B" {
+		t.Fatalf("T(t) after eviction = %q, want B", got)
+	}
+	if got := loc.T("u", nil); got != "A" {
+		t.Fatalf("T(u) after eviction = %q, want A", got)
+	}
+
+	// 再次建立索引（另一个目录中也有 en 文件）同样不会覆盖
+	other := fstest.MapFS{"en.yaml": {Data: []byte("language: en\nmessages:\n  v: C\n")}}
+	if err := bundle.LoadLazyFS(other, ".", LazyOptions{IdleTTL: time.Minute}); err != nil {
+		t.Fatalf("LoadLazyFS: %v", err)
+	}
+	loc = bundle.Locale("en")
+	if got := loc.T("t", nil); got != "B" {
+		t.Fatalf("T(t) after re-index = %q, want B", got)
+	}
+	if got := loc.T("v", nil); got != "C" {
+		t.Fatalf("T(v) after re-index = %q, want C", got)
+	}
+}
+
+func TestBundle_LoadLazyFS_HeaderIndex(t *testing.T) {
+	fsys := fstest.MapFS{
+		// messages 有语法错误：建立索引时不解析，第一次加载时才报错
+		"en.yaml":     {Data: []byte("language: en\nmessages:\n  hello: [unclosed\n")},
+		"fr.yaml":     {Data: []byte("# comment\nlanguage: fr\ninclude:\n- common.yaml\nmessages:\n  hello: Bonjour\n")},
+		"common.yaml": {Data: []byte("messages:\n  brand: ACME\n")},
+		"de.json":     {Data: []byte(`{"messages": {"hello": "Hallo"}, "language": "de"}`)},
+	}
+	bundle := New(Config{})
+	if err := bundle.LoadLazyFS(fsys, ".", LazyOptions{}); err != nil {
+		t.Fatalf("LoadLazyFS: %v", err)
+	}
+	if err := bundle.Preload("en"); err == nil {
+		t.Fatal("expected a parse error when loading en")
+	}
+	if got := bundle.Locale("fr").T("brand", nil); got != "ACME" {
+		t.Fatalf("T: %q", got)
+	}
+	if got := bundle.Locale("de").T("hello", nil); got != "Hallo" {
+		t.Fatalf("T: %q", got)
+	}
+
+	fsys["orphan.yaml"] = &fstest.MapFile{Data: []byte("messages:\n  a: b\n")}
+	if err := New(Config{}).LoadLazyFS(fsys, ".", LazyOptions{}); err == nil {
+		t.Fatal("expected an error for a file without language that nobody includes")
+	}
+}
@@ -1,19 +1,51 @@
 package i18n
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // yamlFile 结构和上面给的示例 YAML 对应
+// messages 既可以是扁平的 `user.login.success: ...`，也可以是嵌套的 map，加载时统一展开为点分隔的 key
+// Messages 保留为 yaml.Node，以便记录每个 key 的行列号
 type yamlFile struct {
-	Language string            `yaml:"language"`
-	Messages map[string]string `yaml:"messages"`
+	Language  string    `yaml:"language"`
+	Namespace string    `yaml:"namespace"`
+	Extends   string    `yaml:"extends"`
+	Include   []string  `yaml:"include"`
+	Version   int       `yaml:"version"`
+	Messages  yaml.Node `yaml:"messages"`
+}
+
+// jsonFile 与 yamlFile 结构相同，用于 `.json` 翻译文件
+type jsonFile struct {
+	Language  string         `json:"language"`
+	Namespace string         `json:"namespace"`
+	Extends   string         `json:"extends"`
+	Include   []string       `json:"include"`
+	Version   int            `json:"version"`
+	Messages  map[string]any `json:"messages"`
+}
+
+// jsonHeader 只包含 jsonFile 的文件头字段，解码时会跳过 messages
+type jsonHeader struct {
+	Language  string   `json:"language"`
+	Namespace string   `json:"namespace"`
+	Extends   string   `json:"extends"`
+	Include   []string `json:"include"`
 }
 
 // Config 定义 i18n 的基础配置
@@ -26,13 +58,58 @@ type Config struct {
 	// "zh": {"zh", "en"}
 	// 如果 Locale 没有显式传 langs，就使用 DefaultLang + 对应 fallback
 	Fallbacks map[string][]string
+
+	// ConflictPolicy 多个文件为同一语言定义同一 key 时的处理方式，默认 ConflictLastWins
+	ConflictPolicy ConflictPolicy
+	// OnConflict ConflictPolicy 为 ConflictWarn 时，每个冲突回调一次
+	OnConflict func(c Conflict)
+
+	// Store 翻译数据的存储，为 nil 时使用内存中的 MemoryStore。
+	// 使用自定义 Store 的 Bundle 不支持热更新（见 ErrCustomStore）
+	Store Store
+
+	// PublicKey 不为空时，从目录、fs.FS 或 HTTP 加载翻译文件前，
+	// 先用它校验文件旁的 detached 签名（文件名加 SignatureExt），签名不匹配的文件会被拒绝
+	PublicKey ed25519.PublicKey
+	// RequireSignature 严格模式：没有签名文件的翻译文件也会被拒绝，
+	// 无法签名的来源（LoadReader、LoadBinary、LoadSQL/RefreshSQL）总是返回 ErrUnsigned
+	RequireSignature bool
+
+	// OnMissingKey Locale.T 找不到翻译时回调，langs 为查找过的语言链
+	OnMissingKey func(langs []string, key string)
+	// OnRenderError Locale.T 找到翻译但渲染失败时回调，lang 为渲染失败的语言，
+	// err 为 *RenderError（FallbackLang 为回退后实际使用的语言）
+	OnRenderError func(lang, key string, err error)
+	// HookDedupWindow 大于 0 时，同一语言、同一 key 的回调在该时间窗口内只触发一次
+	HookDedupWindow time.Duration
+	// HookRateLimit 大于 0 时，两个回调合计每秒最多触发的次数，超出的回调被丢弃
+	HookRateLimit int
+	// RenderFallthrough 为 true 时，某个语言的翻译渲染失败会继续尝试语言链中的下一个语言
+	RenderFallthrough bool
+	// Replace 决定 Locale.T 翻译失败时返回的文本，为 nil 时找不到翻译返回 key，渲染失败返回原文。
+	// 内置 ReplaceKey、ReplaceHumanizedKey、ReplaceMarker 与 ReplaceDefaultLang
+	Replace ReplaceFunc
 }
 
 // Bundle 是整个 i18n 的核心对象，负责持有所有语言的数据
 type Bundle struct {
-	mu       sync.RWMutex
-	messages MessageStore
-	config   Config
+	// wmu 串行化写操作：Store 的写入在 wmu 内、mu 外进行，慢速的 Store 不会阻塞翻译。
+	// 加锁顺序总是先 wmu 后 mu；修改 store/meta/sources 需要同时持有两者
+	wmu sync.Mutex
+
+	mu      sync.RWMutex
+	store   Store
+	meta    map[string]map[string]MessageMeta // lang -> key -> meta
+	sources map[string]map[string]Source      // lang -> key -> 定义该 key 的位置
+	config  Config
+
+	overlays map[string]*Overlay // 租户/品牌覆盖层
+
+	lazy atomic.Pointer[lazyIndex] // 懒加载索引，nil 表示非懒加载模式
+
+	templates atomic.Pointer[map[string]TemplateAST] // LoadBinary 预解析的模板，写时复制
+
+	hooks hookLimiter // OnMissingKey / OnRenderError 的去重与限流
 }
 
 // New 创建一个新的 Bundle
@@ -43,33 +120,232 @@ func New(cfg Config) *Bundle {
 	if cfg.Fallbacks == nil {
 		cfg.Fallbacks = make(map[string][]string)
 	}
+	var store Store = cfg.Store
+	if store == nil {
+		store = NewMemoryStore()
+	}
 	return &Bundle{
-		messages: make(MessageStore),
-		config:   cfg,
+		store:  store,
+		config: cfg,
 	}
 }
 
 // RegisterMessages 注册某个语言的一批翻译信息
-// 通常由 loader.go 调用
-func (b *Bundle) RegisterMessages(lang string, msgs map[string]string) {
+// 通常由 loader.go 调用；需要删除或整体替换时使用 RemoveMessages / ReplaceMessages。
+// Store 写入失败时返回错误，已经写入的翻译不会回滚
+func (b *Bundle) RegisterMessages(lang string, msgs map[string]string) error {
+	b.wmu.Lock()
+	defer b.wmu.Unlock()
+	err := b.mergeLocked(lang, msgs)
+	// 手动注册的翻译不属于任何文件，不参与文件间的冲突检测
 	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	if b.messages == nil {
-		b.messages = make(MessageStore)
+	for k := range msgs {
+		delete(b.sources[lang], k)
 	}
+	b.mu.Unlock()
+	return err
+}
+
+// mergeLocked 合并翻译，遇到第一个写入错误即返回，调用方需持有 b.wmu
+func (b *Bundle) mergeLocked(lang string, msgs map[string]string) error {
 	// 简单做 merge，不做删除
-	if _, ok := b.messages[lang]; !ok {
-		b.messages[lang] = make(map[string]string)
-	}
 	for k, v := range msgs {
-		b.messages[lang][k] = v
+		if err := b.store.Set(lang, k, v); err != nil {
+			return fmt.Errorf("i18n: store %s %s: %w", lang, k, err)
+		}
+	}
+	return nil
+}
+
+// ReplaceMessages 用 msgs 整体替换某个语言的翻译，原有的 key 全部丢弃
+// 默认的 MemoryStore 中替换是原子的，翻译过程中不会看到替换了一半的状态；
+// 自定义 Store 逐条删除、写入
+func (b *Bundle) ReplaceMessages(lang string, msgs map[string]string) error {
+	b.wmu.Lock()
+	defer b.wmu.Unlock()
+	b.mu.Lock()
+	delete(b.meta, lang)
+	delete(b.sources, lang)
+	b.mu.Unlock()
+
+	if ms, ok := b.store.(*MemoryStore); ok {
+		ms.replace(lang, msgs)
+		return nil
+	}
+	keys, err := b.store.Keys(lang)
+	if err != nil {
+		return fmt.Errorf("i18n: store %s: %w", lang, err)
+	}
+	for _, k := range keys {
+		if _, keep := msgs[k]; keep {
+			continue
+		}
+		if err := b.store.Delete(lang, k); err != nil {
+			return fmt.Errorf("i18n: store %s %s: %w", lang, k, err)
+		}
+	}
+	return b.mergeLocked(lang, msgs)
+}
+
+// RemoveMessages 删除某个语言下的若干 key，不存在的 key 忽略
+func (b *Bundle) RemoveMessages(lang string, keys ...string) error {
+	b.wmu.Lock()
+	defer b.wmu.Unlock()
+	return b.deleteLocked(lang, keys)
+}
+
+// UnloadLanguage 删除某个语言的全部翻译
+func (b *Bundle) UnloadLanguage(lang string) error {
+	b.wmu.Lock()
+	defer b.wmu.Unlock()
+	keys, err := b.store.Keys(lang)
+	if err != nil {
+		return fmt.Errorf("i18n: store %s: %w", lang, err)
+	}
+	if err := b.deleteLocked(lang, keys); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	delete(b.meta, lang)
+	delete(b.sources, lang)
+	b.mu.Unlock()
+	return nil
+}
+
+// deleteLocked 从 Store 中删除 keys 以及它们的元信息与定义位置，调用方需持有 b.wmu
+func (b *Bundle) deleteLocked(lang string, keys []string) error {
+	for _, k := range keys {
+		if err := b.store.Delete(lang, k); err != nil {
+			return fmt.Errorf("i18n: store %s %s: %w", lang, k, err)
+		}
+		b.mu.Lock()
+		delete(b.meta[lang], k)
+		delete(b.sources[lang], k)
+		b.mu.Unlock()
+	}
+	return nil
+}
+
+// Clone 返回一个深拷贝的 Bundle，两者之后的修改互不影响
+// 常用于测试：从一个已知状态出发，随意修改而不污染原 Bundle。
+// 懒加载模式下只复制已经加载的翻译；覆盖层会一并复制。
+// 翻译总是被复制到新的 MemoryStore 中，复制过程中读取 Store 失败时返回错误
+func (b *Bundle) Clone() (*Bundle, error) {
+	b.wmu.Lock()
+	msgs, err := copyStore(b.store)
+	if err != nil {
+		b.wmu.Unlock()
+		return nil, err
+	}
+
+	b.mu.RLock()
+	cfg := b.config
+	cfg.Store = nil
+	cfg.Fallbacks = make(map[string][]string, len(b.config.Fallbacks))
+	for lang, chain := range b.config.Fallbacks {
+		cfg.Fallbacks[lang] = append([]string(nil), chain...)
+	}
+	meta := make(map[string]map[string]MessageMeta, len(b.meta))
+	for lang, m := range b.meta {
+		cp := make(map[string]MessageMeta, len(m))
+		for k, v := range m {
+			cp[k] = v
+		}
+		meta[lang] = cp
+	}
+	sources := make(map[string]map[string]Source, len(b.sources))
+	for lang, m := range b.sources {
+		cp := make(map[string]Source, len(m))
+		for k, v := range m {
+			cp[k] = v
+		}
+		sources[lang] = cp
+	}
+	overlays := make(map[string]*Overlay, len(b.overlays))
+	for name, ov := range b.overlays {
+		overlays[name] = ov
+	}
+	b.mu.RUnlock()
+	b.wmu.Unlock()
+
+	clone := &Bundle{
+		store:   msgs,
+		meta:    meta,
+		sources: sources,
+		config:  cfg,
+	}
+	// 模板表只会整体替换，可以直接共享
+	clone.templates.Store(b.templates.Load())
+	// 覆盖层在释放 b 的锁之后再复制，不同时持有两个 Bundle 的锁
+	if len(overlays) > 0 {
+		clone.overlays = make(map[string]*Overlay, len(overlays))
+		for name, ov := range overlays {
+			layer, err := ov.layer.Clone()
+			if err != nil {
+				return nil, fmt.Errorf("overlay %s: %w", name, err)
+			}
+			clone.overlays[name] = &Overlay{name: name, base: clone, layer: layer}
+		}
+	}
+	return clone, nil
+}
+
+// copyStore 把 store 中的全部翻译复制到新的 MemoryStore
+func copyStore(store Store) (*MemoryStore, error) {
+	msgs := NewMemoryStore()
+	langs, err := store.Languages()
+	if err != nil {
+		return nil, fmt.Errorf("i18n: store: %w", err)
+	}
+	for _, lang := range langs {
+		keys, err := store.Keys(lang)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: store %s: %w", lang, err)
+		}
+		for _, k := range keys {
+			text, ok, err := store.Get(lang, k)
+			if err != nil {
+				return nil, fmt.Errorf("i18n: store %s %s: %w", lang, k, err)
+			}
+			if ok {
+				msgs.msgs.Set(lang, k, text)
+			}
+		}
 	}
+	return msgs, nil
+}
+
+// swap 用 fresh 中的翻译整体替换当前翻译
+func (b *Bundle) swap(fresh *Bundle) {
+	fresh.mu.RLock()
+	store, meta, sources := fresh.store, fresh.meta, fresh.sources
+	fresh.mu.RUnlock()
+
+	b.wmu.Lock()
+	defer b.wmu.Unlock()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.store = store
+	b.meta = meta
+	b.sources = sources
 }
 
 // Locale 返回一个 Locale 视图，用于在业务中做翻译
 // lang 可以是 "zh-CN" / "en" 等
+// 懒加载模式下（见 LoadLazyFS），会先加载语言链中尚未加载的语言
 func (b *Bundle) Locale(lang string) *Locale {
+	chain := b.fallbackChain(lang)
+	if idx := b.lazy.Load(); idx != nil {
+		idx.ensure(b, chain)
+	}
+	return &Locale{
+		bundle: b,
+		langs:  chain,
+	}
+}
+
+// fallbackChain 计算 lang 对应的语言链
+func (b *Bundle) fallbackChain(lang string) []string {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
@@ -88,53 +364,508 @@ func (b *Bundle) Locale(lang string) *Locale {
 	} else {
 		chain = append(chain, b.config.DefaultLang)
 	}
+	return chain
+}
 
-	return &Locale{
-		bundle: b,
-		langs:  chain,
+// LocaleFile 是单个翻译文件解析后的结果，运行时加载和 i18nlint 共用同一份解析逻辑
+type LocaleFile struct {
+	// Path 文件路径（相对于所在 fs.FS 的根）
+	Path     string
+	Language string
+	// Namespace 为空表示全局命名空间，否则 Messages 中的 key 注册为 "namespace:key"
+	Namespace string
+	Messages  map[string]string
+	// Meta 消息的附加信息，只包含以对象形式定义的消息
+	Meta map[string]MessageMeta
+	// Sources 每个 key 在文件中的位置，Loader 不提供时为空
+	Sources map[string]Source
+
+	// Extends 继承的父语言，例如 zh-TW 继承 zh-CN，只需要写出差异的 key
+	Extends string
+	// Include 合并进本文件的其他文件（路径相对于本文件所在目录），本文件中的 key 优先
+	Include []string
+	// InheritedFrom 不为空表示这是从父语言继承而来的翻译（由 extends 展开生成），
+	// 注册时只补充缺失的 key，不覆盖也不参与冲突检测
+	InheritedFrom string
+
+	origin any // 见 Source.origin
+}
+
+// setPath 设置文件路径，并同步到 Sources 中
+func (f *LocaleFile) setPath(p string) {
+	f.Path = p
+	for k, src := range f.Sources {
+		src.File = p
+		f.Sources[k] = src
 	}
 }
 
-// LoadYAMLDir 从目录中加载所有 `.yaml/.yml` 文件
-// 例如: ./locales/en.yaml, ./locales/zh-CN.yaml
-func (b *Bundle) LoadYAMLDir(dir string) error {
-	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+// isLocaleFile 判断文件扩展名是否注册了 Loader
+func isLocaleFile(name string) bool {
+	_, ok := lookupLoader(path.Ext(name))
+	return ok
+}
+
+// ParseLocaleFile 从 r 中读取并解析一个翻译文件
+// name 用于按扩展名选择 Loader（见 RegisterLoader）以及错误信息。
+// 单个文件无法解析 include，extends 需要与父语言一起加载，二者在这里只解析不处理
+func ParseLocaleFile(name string, r io.Reader) (*LocaleFile, error) {
+	f, err := parseLocaleFile(name, r)
+	if err != nil {
+		return nil, err
+	}
+	if f.Language == "" {
+		return nil, fmt.Errorf("file %s missing 'language' field", name)
+	}
+	return f, nil
+}
+
+// parseLocaleFile 与 ParseLocaleFile 相同，但允许缺少 language（被 include 的片段文件）
+func parseLocaleFile(name string, r io.Reader) (*LocaleFile, error) {
+	loader, ok := lookupLoader(path.Ext(name))
+	if !ok {
+		return nil, fmt.Errorf("no loader registered for %q", path.Ext(name))
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f, err := loader.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	f.setPath(name)
+	return f, nil
+}
+
+// yamlLoader 解析 `.yaml/.yml` 文件
+type yamlLoader struct{}
+
+// 通过 yaml.Node 解码以保留每个 key 的行列号
+func (yamlLoader) Load(data []byte) (*LocaleFile, error) {
+	var yf yamlFile
+	if err := yaml.Unmarshal(data, &yf); err != nil {
+		return nil, fmt.Errorf("yaml unmarshal: %w", err)
+	}
+	tree, sources, err := decodeYAMLMessages(&yf.Messages)
+	if err != nil {
+		return nil, err
+	}
+	f, err := NewLocaleFile(yf.Language, yf.Namespace, yf.Version, tree)
+	if err != nil {
+		var ke *keyError
+		if errors.As(err, &ke) {
+			if src, ok := sources[ke.key]; ok {
+				return nil, fmt.Errorf("line %d, column %d: %w", src.Line, src.Column, err)
+			}
+		}
+		return nil, err
+	}
+	f.Sources = make(map[string]Source, len(f.Messages))
+	for k := range f.Messages {
+		f.Sources[k] = sources[k]
+	}
+	f.Extends, f.Include = yf.Extends, yf.Include
+	return f, nil
+}
+
+// yamlHeaderKeys 文件头中的顶层字段
+var yamlHeaderKeys = [][]byte{[]byte("language:"), []byte("namespace:"), []byte("extends:"), []byte("include:")}
+
+// LoadHeader 只挑出文件头字段所在的顶层块再解码，跳过 messages
+func (yamlLoader) LoadHeader(data []byte) (*LocaleFile, error) {
+	var head []byte
+	keep := false
+	for len(data) > 0 {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i+1], data[i+1:]
+		} else {
+			data = nil
+		}
+		// 顶层 key 开始一个新块；缩进行、注释、空行与顶格的序列项属于当前块
+		switch line[0] {
+		case ' ', '\t', '#', '\r', '\n', '-':
+		default:
+			keep = false
+			for _, k := range yamlHeaderKeys {
+				if bytes.HasPrefix(line, k) {
+					keep = true
+					break
+				}
+			}
+		}
+		if keep {
+			head = append(head, line...)
+		}
+	}
+	var yf yamlFile
+	if err := yaml.Unmarshal(head, &yf); err != nil {
+		return nil, fmt.Errorf("yaml unmarshal: %w", err)
+	}
+	return &LocaleFile{Language: yf.Language, Namespace: yf.Namespace, Extends: yf.Extends, Include: yf.Include}, nil
+}
+
+// jsonLoader 解析 `.json` 文件
+type jsonLoader struct{}
+
+func (jsonLoader) Load(data []byte) (*LocaleFile, error) {
+	var jf jsonFile
+	if err := json.Unmarshal(data, &jf); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+	f, err := NewLocaleFile(jf.Language, jf.Namespace, jf.Version, jf.Messages)
+	if err != nil {
+		return nil, err
+	}
+	f.Extends, f.Include = jf.Extends, jf.Include
+	return f, nil
+}
+
+// LoadHeader 只解码文件头字段
+func (jsonLoader) LoadHeader(data []byte) (*LocaleFile, error) {
+	var jh jsonHeader
+	if err := json.Unmarshal(data, &jh); err != nil {
+		return nil, fmt.Errorf("json unmarshal: %w", err)
+	}
+	return &LocaleFile{Language: jh.Language, Namespace: jh.Namespace, Extends: jh.Extends, Include: jh.Include}, nil
+}
+
+// NewLocaleFile 用解码后的 messages 树构造 LocaleFile 并展开嵌套结构，自定义 Loader 可以直接复用。
+// version 为文件声明的格式版本：为 MessageObjectVersion 时提取消息对象中的元信息（见 MessageMeta），
+// 为 0 或 1 时对象一律按嵌套 map 展开
+func NewLocaleFile(language, namespace string, version int, tree map[string]any) (*LocaleFile, error) {
+	if version > MessageObjectVersion {
+		return nil, fmt.Errorf("unsupported file version %d", version)
+	}
+	msgs, meta, err := flattenTree(tree, version == MessageObjectVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &LocaleFile{Language: language, Namespace: namespace, Messages: msgs, Meta: meta}, nil
+}
+
+// FlattenMessages 把嵌套的 messages 展开为 Locale.T 使用的点分隔 key：
+//
+//	user:
+//	  login:
+//	    success: "..."   =>   user.login.success: "..."
+//
+// 扁平与嵌套写法可以混用；同一个 key 重复定义，或者某个 key 既是叶子又是父节点时返回错误。
+// 不识别消息对象（见 MessageMeta），需要时使用 NewLocaleFile
+func FlattenMessages(tree map[string]any) (map[string]string, error) {
+	msgs, _, err := flattenTree(tree, false)
+	return msgs, err
+}
+
+// flattenTree 展开 messages 树，objects 为 true 时识别消息对象
+func flattenTree(tree map[string]any, objects bool) (map[string]string, map[string]MessageMeta, error) {
+	out := make(map[string]string, len(tree))
+	meta := make(map[string]MessageMeta)
+	if err := flattenInto(out, meta, "", tree, objects); err != nil {
+		return nil, nil, err
+	}
+	for key := range out {
+		for i := 0; i < len(key); i++ {
+			if key[i] != '.' {
+				continue
+			}
+			if _, ok := out[key[:i]]; ok {
+				return nil, nil, &keyError{key: key[:i], err: fmt.Errorf("key %q is both a message and a parent of %q", key[:i], key)}
+			}
+		}
+	}
+	if len(meta) == 0 {
+		meta = nil
+	}
+	return out, meta, nil
+}
+
+func flattenInto(out map[string]string, meta map[string]MessageMeta, prefix string, node map[string]any, objects bool) error {
+	for k, v := range node {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if child, ok := v.(map[string]any); ok {
+			if objects {
+				text, m, isMsg, err := parseMessageObject(key, child)
+				if err != nil {
+					return err
+				}
+				if isMsg {
+					if _, dup := out[key]; dup {
+						return &keyError{key: key, err: fmt.Errorf("duplicate key %q", key)}
+					}
+					out[key] = text
+					meta[key] = m
+					continue
+				}
+			}
+			if err := flattenInto(out, meta, key, child, objects); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, dup := out[key]; dup {
+			return &keyError{key: key, err: fmt.Errorf("duplicate key %q", key)}
+		}
+		switch vv := v.(type) {
+		case string:
+			out[key] = vv
+		case nil:
+			out[key] = ""
+		case []any:
+			return &keyError{key: key, err: fmt.Errorf("key %q: list is not a valid message", key)}
+		default:
+			out[key] = fmt.Sprint(vv)
+		}
+	}
+	return nil
+}
+
+// ReadLocaleFS 遍历 fsys 中 root 目录下所有的翻译文件并逐个解析
+// 支持 os.DirFS / embed.FS 等任意 fs.FS 实现
+func ReadLocaleFS(fsys fs.FS, root string) ([]*LocaleFile, error) {
+	return ReadNamespaceFS("", fsys, root)
+}
+
+// ReadNamespaceFS 与 ReadLocaleFS 相同，但目录下没有声明 namespace 的文件归入 ns；
+// 文件自身声明的 namespace 与 ns 不一致时返回错误
+//
+// 返回前会展开 include 与 extends：被 include 的片段文件（没有 language）不会单独返回，
+// 继承自父语言的翻译以 InheritedFrom 不为空的 LocaleFile 追加在结果末尾
+func ReadNamespaceFS(ns string, fsys fs.FS, root string) ([]*LocaleFile, error) {
+	var files []*LocaleFile
+	included := make(map[string]struct{})
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if d.IsDir() {
+		if d.IsDir() || !isLocaleFile(p) {
 			return nil
 		}
-		ext := filepath.Ext(path)
-		if ext != ".yaml" && ext != ".yml" {
-			return nil
+		f, err := readLocaleFileIncludes(fsys, p, included)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", p, err)
 		}
-		if err := b.loadYAMLFile(path); err != nil {
-			return fmt.Errorf("loadYAMLFile %s: %w", path, err)
+		if ns != "" {
+			if f.Namespace != "" && f.Namespace != ns {
+				return fmt.Errorf("load %s: namespace %q conflicts with directory namespace %q", p, f.Namespace, ns)
+			}
+			f.Namespace = ns
 		}
+		files = append(files, f)
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 没有 language 的文件只允许作为 include 片段存在
+	own := files[:0]
+	for _, f := range files {
+		if f.Language != "" {
+			own = append(own, f)
+			continue
+		}
+		if _, ok := included[f.Path]; !ok {
+			return nil, fmt.Errorf("load %s: file %s missing 'language' field", f.Path, f.Path)
+		}
+	}
+	inherited, err := resolveExtends(own)
+	if err != nil {
+		return nil, err
+	}
+	return append(own, inherited...), nil
+}
+
+// ReadLocaleDir 读取操作系统目录 dir，返回的 Path 包含 dir 前缀，
+// 便于在冲突、语法错误等信息中区分不同目录下的同名文件
+func ReadLocaleDir(ns, dir string) ([]*LocaleFile, error) {
+	return readLocaleDirFS(ns, dir, os.DirFS(dir))
+}
+
+// readLocaleDirFS 与 ReadLocaleDir 相同，但从 fsys（通常是包装过的 os.DirFS(dir)）中读取
+func readLocaleDirFS(ns, dir string, fsys fs.FS) ([]*LocaleFile, error) {
+	files, err := ReadNamespaceFS(ns, fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+	for _, f := range files {
+		f.setPath(filepath.Join(dir, filepath.FromSlash(f.Path)))
+	}
+	return files, nil
+}
+
+func readLocaleFile(fsys fs.FS, name string) (*LocaleFile, error) {
+	return readLocaleFileIncludes(fsys, name, nil)
+}
+
+// readLocaleFileIncludes 读取并解析文件，展开其 include；
+// included 不为 nil 时记录所有被 include 的文件路径
+func readLocaleFileIncludes(fsys fs.FS, name string, included map[string]struct{}) (*LocaleFile, error) {
+	f, err := openLocaleFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveIncludes(fsys, f, []string{name}, included); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func openLocaleFile(fsys fs.FS, name string) (*LocaleFile, error) {
+	fh, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	return parseLocaleFile(name, fh)
+}
+
+// LoadFS 从任意 fs.FS 中加载 root 目录下的所有翻译文件
+// 配合 //go:embed 可以把翻译文件直接打包进二进制：
+//
+//	//go:embed locales
+//	var localeFS embed.FS
+//
+//	bundle.LoadFS(localeFS, "locales")
+func (b *Bundle) LoadFS(fsys fs.FS, root string) error {
+	return b.LoadNamespaceFS("", fsys, root)
 }
 
-func (b *Bundle) loadYAMLFile(path string) error {
-	data, err := os.ReadFile(path)
+// LoadNamespaceFS 从 fsys 中加载 root 目录，目录下的文件默认归入命名空间 ns，
+// 之后可以用 loc.T("ns:key", args) 查找
+func (b *Bundle) LoadNamespaceFS(ns string, fsys fs.FS, root string) error {
+	files, err := ReadNamespaceFS(ns, b.verifiedFS(fsys), root)
 	if err != nil {
 		return err
 	}
-	var yf yamlFile
-	if err := yaml.Unmarshal(data, &yf); err != nil {
-		return fmt.Errorf("yaml unmarshal: %w", err)
+	origin := fsOrigin(fsys)
+	for _, f := range files {
+		f.origin = origin
 	}
-	if yf.Language == "" {
-		return fmt.Errorf("file %s missing 'language' field", path)
+	return b.registerFiles(files)
+}
+
+// LoadReader 从 io.Reader 中加载单个翻译文件，name 用于错误信息
+// 单个 Reader 没有签名文件，严格模式（Config.RequireSignature）下总是返回 ErrUnsigned
+func (b *Bundle) LoadReader(name string, r io.Reader) error {
+	if b.config.RequireSignature {
+		return fmt.Errorf("load %s: %w", name, ErrUnsigned)
 	}
-	if len(yf.Messages) == 0 {
-		return nil
+	f, err := ParseLocaleFile(name, r)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", name, err)
+	}
+	if f.Extends != "" || len(f.Include) > 0 {
+		return fmt.Errorf("load %s: extends/include require loading from a directory or fs.FS", name)
+	}
+	return b.registerFiles([]*LocaleFile{f})
+}
+
+// registerFiles 注册一批文件的翻译，按 Config.ConflictPolicy 处理与其他文件（包括同一批中的文件）的冲突。
+// 整批在同一次 b.wmu 内完成：ConflictError 时先检查全部文件，有冲突则整批都不注册
+func (b *Bundle) registerFiles(files []*LocaleFile) error {
+	return b.registerFilesWith(files, nil)
+}
+
+// registerFilesWith 与 registerFiles 相同；filter 不为 nil 时，先在 b.wmu 内用它过滤 files
+func (b *Bundle) registerFilesWith(files []*LocaleFile, filter func([]*LocaleFile) ([]*LocaleFile, error)) error {
+	b.wmu.Lock()
+	if filter != nil {
+		var err error
+		if files, err = filter(files); err != nil {
+			b.wmu.Unlock()
+			return err
+		}
+	}
+	if b.config.ConflictPolicy == ConflictError {
+		if err := b.checkConflictsLocked(files); err != nil {
+			b.wmu.Unlock()
+			return err
+		}
+	}
+	var conflicts []Conflict
+	for _, f := range files {
+		if len(f.Messages) == 0 {
+			continue
+		}
+		msgs := f.Messages
+		if f.Namespace != "" {
+			msgs = make(map[string]string, len(f.Messages))
+			for k, v := range f.Messages {
+				msgs[NamespacedKey(f.Namespace, k)] = v
+			}
+		}
+		msgs, found, err := b.resolveConflictsLocked(f, msgs)
+		if err == nil {
+			err = b.mergeLocked(f.Language, msgs)
+		}
+		if err != nil {
+			b.wmu.Unlock()
+			return fmt.Errorf("load %s: %w", f.Path, err)
+		}
+		conflicts = append(conflicts, found...)
+
+		b.mu.Lock()
+		b.trackSourcesLocked(f, msgs)
+		if len(f.Meta) > 0 {
+			if b.meta == nil {
+				b.meta = make(map[string]map[string]MessageMeta)
+			}
+			if b.meta[f.Language] == nil {
+				b.meta[f.Language] = make(map[string]MessageMeta)
+			}
+			for k, m := range f.Meta {
+				key := NamespacedKey(f.Namespace, k)
+				if _, ok := msgs[key]; ok {
+					b.meta[f.Language][key] = m
+				}
+			}
+		}
+		b.mu.Unlock()
+	}
+	b.wmu.Unlock()
+
+	// 回调放在锁外，允许回调中访问 Bundle
+	if onConflict := b.config.OnConflict; onConflict != nil {
+		for _, c := range conflicts {
+			onConflict(c)
+		}
 	}
-	b.RegisterMessages(yf.Language, yf.Messages)
 	return nil
 }
 
+// LoadDir 从目录中加载所有 `.yaml/.yml/.json` 文件，不同格式可以放在同一目录
+// 例如: ./locales/en.yaml, ./locales/zh-CN.json
+func (b *Bundle) LoadDir(dir string) error {
+	return b.LoadNamespaceDir("", dir)
+}
+
+// LoadNamespaceDir 从目录中加载翻译文件，目录下的文件默认归入命名空间 ns
+func (b *Bundle) LoadNamespaceDir(ns, dir string) error {
+	files, err := readLocaleDirFS(ns, dir, b.verifiedFS(os.DirFS(dir)))
+	if err != nil {
+		return err
+	}
+	return b.registerFiles(files)
+}
+
+// LoadYAMLDir 从目录中加载翻译文件，等同于 LoadDir，保留以兼容旧代码
+// 例如: ./locales/en.yaml, ./locales/zh-CN.yaml
+func (b *Bundle) LoadYAMLDir(dir string) error {
+	return b.LoadDir(dir)
+}
+
+// MustLoadDir 版本，在初始化阶段直接 panic
+func (b *Bundle) MustLoadDir(dir string) {
+	if err := b.LoadDir(dir); err != nil {
+		panic(err)
+	}
+}
+
 // MustLoadYAMLDir 版本，在初始化阶段直接 panic
 func (b *Bundle) MustLoadYAMLDir(dir string) {
 	if err := b.LoadYAMLDir(dir); err != nil {
@@ -1,8 +1,11 @@
 package i18n
 
 import (
+	"fmt"
 	"slices"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestBundle_LoadYAMLDir(t *testing.T) {
@@ -39,3 +42,254 @@ func TestBundle_Locale(t *testing.T) {
 		}
 	})
 }
+
+func TestBundle_LoadFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.yaml": {Data: []byte("language: en\nmessages:\n  hello: \"Hello, {name}\"\n")},
+		"locales/README":  {Data: []byte("not a locale file")},
+	}
+	bundle := New(Config{})
+	if err := bundle.LoadFS(fsys, "locales"); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	if got := bundle.Locale("en").T("hello", map[string]any{"name": "Tom"}); got != "Hello, Tom" {
+		t.Fatalf("T: %q", got)
+	}
+}
+
+func TestBundle_LoadReader(t *testing.T) {
+	t.Run("Bundle_LoadReader_Success", func(t *testing.T) {
+		bundle := New(Config{})
+		err := bundle.LoadReader("zh-CN.yaml", strings.NewReader("language: zh-CN\nmessages:\n  hello: 你好\n"))
+		if err != nil {
+			t.Fatalf("LoadReader: %v", err)
+		}
+		if got := bundle.Locale("zh-CN").T("hello", nil); got != "你好" {
+			t.Fatalf("T: %q", got)
+		}
+	})
+	t.Run("Bundle_LoadReader_MissingLanguage", func(t *testing.T) {
+		bundle := New(Config{})
+		err := bundle.LoadReader("bad.yaml", strings.NewReader("messages:\n  hello: hi\n"))
+		if err == nil {
+			t.Fatal("expected error for missing language")
+		}
+	})
+}
+
+func TestBundle_LoadFS_JSON(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.yaml":  {Data: []byte("language: en\nmessages:\n  hello: \"Hello, {name}\"\n")},
+		"fr.json":  {Data: []byte(`{"language": "fr", "messages": {"hello": "Bonjour, {name}"}}`)},
+		"bad.json": {Data: []byte(`{"language": "de", "messages": ["oops"]}`)},
+	}
+	bundle := New(Config{})
+	if err := bundle.LoadFS(fsys, "."); err == nil {
+		t.Fatal("expected error for malformed json")
+	}
+
+	delete(fsys, "bad.json")
+	bundle = New(Config{})
+	if err := bundle.LoadFS(fsys, "."); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	if got := bundle.Locale("fr").T("hello", map[string]any{"name": "Tom"}); got != "Bonjour, Tom" {
+		t.Fatalf("T: %q", got)
+	}
+}
+
+func TestParseLocaleFile_Nested(t *testing.T) {
+	t.Run("ParseLocaleFile_Nested_Success", func(t *testing.T) {
+		src := `language: en
+messages:
+  common.hello: "Hello"
+  user:
+    login:
+      success: "Welcome back, {name}!"
+      failed: "Invalid username or password."
+    logout: "Bye"
+`
+		f, err := ParseLocaleFile("en.yaml", strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("ParseLocaleFile: %v", err)
+		}
+		want := map[string]string{
+			"common.hello":       "Hello",
+			"user.login.success": "Welcome back, {name}!",
+			"user.login.failed":  "Invalid username or password.",
+			"user.logout":        "Bye",
+		}
+		if len(f.Messages) != len(want) {
+			t.Fatalf("Messages: %v", f.Messages)
+		}
+		for k, v := range want {
+			if f.Messages[k] != v {
+				t.Fatalf("Messages[%q] = %q, want %q", k, f.Messages[k], v)
+			}
+		}
+	})
+	t.Run("ParseLocaleFile_Nested_JSON", func(t *testing.T) {
+		src := `{"language": "en", "messages": {"user": {"login": {"success": "hi"}}}}`
+		f, err := ParseLocaleFile("en.json", strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("ParseLocaleFile: %v", err)
+		}
+		if f.Messages["user.login.success"] != "hi" {
+			t.Fatalf("Messages: %v", f.Messages)
+		}
+	})
+	t.Run("ParseLocaleFile_Nested_LeafParentCollision", func(t *testing.T) {
+		src := `language: en
+messages:
+  user.login: "Login"
+  user:
+    login:
+      success: "ok"
+`
+		if _, err := ParseLocaleFile("en.yaml", strings.NewReader(src)); err == nil {
+			t.Fatal("expected collision error")
+		}
+	})
+	t.Run("ParseLocaleFile_Nested_Duplicate", func(t *testing.T) {
+		src := `language: en
+messages:
+  user.login.success: "a"
+  user:
+    login.success: "b"
+`
+		if _, err := ParseLocaleFile("en.yaml", strings.NewReader(src)); err == nil {
+			t.Fatal("expected duplicate key error")
+		}
+	})
+}
+
+func TestBundle_ManageMessages(t *testing.T) {
+	base := New(Config{})
+	base.RegisterMessages("en", map[string]string{"a": "A", "b": "B", "c": "C"})
+	base.RegisterMessages("fr", map[string]string{"a": "A-fr"})
+
+	t.Run("Bundle_ReplaceMessages", func(t *testing.T) {
+		b := mustClone(t, base)
+		b.ReplaceMessages("en", map[string]string{"d": "D"})
+		loc := b.Locale("en")
+		if got := loc.T("a", nil); got != "a" {
+			t.Fatalf("old key should be gone, got %q", got)
+		}
+		if got := loc.T("d", nil); got != "D" {
+			t.Fatalf("T: %q", got)
+		}
+	})
+	t.Run("Bundle_RemoveMessages", func(t *testing.T) {
+		b := mustClone(t, base)
+		b.RemoveMessages("en", "a", "b", "missing")
+		b.RemoveMessages("de", "a")
+		loc := b.Locale("en")
+		if got := loc.T("a", nil); got != "a" {
+			t.Fatalf("T: %q", got)
+		}
+		if got := loc.T("c", nil); got != "C" {
+			t.Fatalf("T: %q", got)
+		}
+	})
+	t.Run("Bundle_UnloadLanguage", func(t *testing.T) {
+		b := mustClone(t, base)
+		b.UnloadLanguage("fr")
+		if got := b.Locale("fr").T("a", nil); got != "A" {
+			t.Fatalf("should fall back to default language, got %q", got)
+		}
+	})
+	t.Run("Bundle_Clone_Isolated", func(t *testing.T) {
+		b := mustClone(t, base)
+		b.RegisterMessages("en", map[string]string{"a": "changed"})
+		if got := base.Locale("en").T("a", nil); got != "A" {
+			t.Fatalf("clone modified original: %q", got)
+		}
+	})
+}
+
+func TestBundle_Namespace(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common/en.yaml":  {Data: []byte("language: en\nmessages:\n  title: Home\n  hello: Hello\n")},
+		"billing/en.yaml": {Data: []byte("language: en\nnamespace: billing\nmessages:\n  title: Invoice\n")},
+		"auth/en.yaml":    {Data: []byte("language: en\nmessages:\n  title: Sign in\n")},
+	}
+	bundle := New(Config{})
+	if err := bundle.LoadFS(fsys, "common"); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	if err := bundle.LoadFS(fsys, "billing"); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	if err := bundle.LoadNamespaceFS("auth", fsys, "auth"); err != nil {
+		t.Fatalf("LoadNamespaceFS: %v", err)
+	}
+
+	loc := bundle.Locale("en")
+	cases := map[string]string{
+		"title":         "Home",
+		"billing:title": "Invoice",
+		"auth:title":    "Sign in",
+	}
+	for key, want := range cases {
+		if got := loc.T(key, nil); got != want {
+			t.Fatalf("T(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	billing := loc.WithNamespace("billing")
+	if got := billing.T("title", nil); got != "Invoice" {
+		t.Fatalf("T: %q", got)
+	}
+	if got := billing.T("hello", nil); got != "Hello" {
+		t.Fatalf("should fall back to global namespace, got %q", got)
+	}
+	if got := billing.T("auth:title", nil); got != "Sign in" {
+		t.Fatalf("T: %q", got)
+	}
+
+	if err := bundle.LoadNamespaceFS("auth", fsys, "billing"); err == nil {
+		t.Fatal("expected namespace conflict error")
+	}
+}
+
+func TestRegisterLoader(t *testing.T) {
+	// 一个简单的 "key=value" 格式，第一行为语言
+	t.Cleanup(func() { UnregisterLoader("kv") })
+	RegisterLoader("kv", LoaderFunc(func(data []byte) (*LocaleFile, error) {
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		f := &LocaleFile{Language: lines[0], Messages: map[string]string{}}
+		for _, line := range lines[1:] {
+			k, v, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid line %q", line)
+			}
+			f.Messages[k] = v
+		}
+		return f, nil
+	}))
+
+	fsys := fstest.MapFS{
+		"en.yaml": {Data: []byte("language: en\nmessages:\n  hello: Hello\n")},
+		"de.KV":   {Data: []byte("de\nhello=Hallo {name}\n")},
+	}
+	bundle := New(Config{})
+	if err := bundle.LoadFS(fsys, "."); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	if got := bundle.Locale("de").T("hello", map[string]any{"name": "Tom"}); got != "Hallo Tom" {
+		t.Fatalf("T: %q", got)
+	}
+
+	if _, err := ParseLocaleFile("de.ini", strings.NewReader("")); err == nil {
+		t.Fatal("expected error for unregistered extension")
+	}
+}
+
+func mustClone(t *testing.T, b *Bundle) *Bundle {
+	t.Helper()
+	clone, err := b.Clone()
+	if err != nil {
+		t.Fatalf("Clone: %v", err)
+	}
+	return clone
+}
@@ -0,0 +1,102 @@
+package i18n
+
+import "fmt"
+
+// MessageMeta 是翻译条目的附加信息，供译者和 i18nlint 使用，不影响渲染。
+// 在声明了 `version: 2` 的翻译文件中把消息写成对象即可携带：
+//
+//	version: 2
+//	messages:
+//	  checkout.button:
+//	    text: "Pay {amount | currency}"
+//	    description: "Primary button on the checkout page"
+//	    context: "button"
+//	    maxLength: 24
+//	    deprecated: false
+//	    tags: [checkout, ui]
+type MessageMeta struct {
+	Description string
+	Context     string
+	// MaxLength 大于 0 时，i18nlint 会对超长（按字符数计算原文）的翻译给出警告
+	MaxLength int
+	// Deprecated 为 true 时，i18nlint 会对代码中仍在使用的 key 给出警告
+	Deprecated bool
+	Tags       []string
+}
+
+// MessageObjectVersion 启用消息对象的文件格式版本。
+// 没有声明版本的文件保持原来的语义：`foo.text` 等嵌套 key 不会被当作消息对象
+const MessageObjectVersion = 2
+
+// messageObjectFields 消息对象允许的字段，出现其他字段时按嵌套 map 处理
+var messageObjectFields = map[string]struct{}{
+	"text":        {},
+	"description": {},
+	"context":     {},
+	"maxLength":   {},
+	"deprecated":  {},
+	"tags":        {},
+}
+
+// parseMessageObject 判断 m 是否为消息对象：包含字符串类型的 text，且只包含 messageObjectFields 中的字段。
+// 不是消息对象时 isMsg 为 false，调用方按嵌套 map 继续展开
+func parseMessageObject(key string, m map[string]any) (text string, meta MessageMeta, isMsg bool, err error) {
+	text, ok := m["text"].(string)
+	if !ok {
+		return "", meta, false, nil
+	}
+	for k := range m {
+		if _, known := messageObjectFields[k]; !known {
+			return "", meta, false, nil
+		}
+	}
+
+	if v, ok := m["description"]; ok {
+		if meta.Description, ok = v.(string); !ok {
+			return "", meta, false, &keyError{key: key, err: fmt.Errorf("key %q: description must be a string", key)}
+		}
+	}
+	if v, ok := m["context"]; ok {
+		if meta.Context, ok = v.(string); !ok {
+			return "", meta, false, &keyError{key: key, err: fmt.Errorf("key %q: context must be a string", key)}
+		}
+	}
+	if v, ok := m["maxLength"]; ok {
+		// YAML 解码为 int，JSON 解码为 float64
+		switch n := v.(type) {
+		case int:
+			meta.MaxLength = n
+		case float64:
+			meta.MaxLength = int(n)
+		default:
+			return "", meta, false, &keyError{key: key, err: fmt.Errorf("key %q: maxLength must be a number", key)}
+		}
+	}
+	if v, ok := m["deprecated"]; ok {
+		if meta.Deprecated, ok = v.(bool); !ok {
+			return "", meta, false, &keyError{key: key, err: fmt.Errorf("key %q: deprecated must be a bool", key)}
+		}
+	}
+	if v, ok := m["tags"]; ok {
+		list, ok := v.([]any)
+		if !ok {
+			return "", meta, false, &keyError{key: key, err: fmt.Errorf("key %q: tags must be a list", key)}
+		}
+		for _, t := range list {
+			s, ok := t.(string)
+			if !ok {
+				return "", meta, false, &keyError{key: key, err: fmt.Errorf("key %q: tags must be strings", key)}
+			}
+			meta.Tags = append(meta.Tags, s)
+		}
+	}
+	return text, meta, true, nil
+}
+
+// Meta 返回某个语言下 key 的附加信息，key 可以带命名空间前缀
+func (b *Bundle) Meta(lang, key string) (MessageMeta, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	m, ok := b.meta[lang][key]
+	return m, ok
+}
@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseLocaleFile_MessageObject(t *testing.T) {
+	t.Run("MessageObject_YAML", func(t *testing.T) {
+		src := `language: en
+version: 2
+messages:
+  checkout:
+    button:
+      text: "Pay {amount}"
+      description: "Primary button on the checkout page"
+      context: button
+      maxLength: 24
+      tags: [checkout, ui]
+    title: "Checkout"
+  legacy:
+    text: "Old"
+    deprecated: true
+  nested:
+    text: "not a message object"
+    other: "because of the unknown field"
+`
+		f, err := ParseLocaleFile("en.yaml", strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("ParseLocaleFile: %v", err)
+		}
+		if got := f.Messages["checkout.button"]; got != "Pay {amount}" {
+			t.Fatalf("Messages[checkout.button] = %q", got)
+		}
+		m := f.Meta["checkout.button"]
+		if m.MaxLength != 24 || m.Context != "button" || !slices.Equal(m.Tags, []string{"checkout", "ui"}) {
+			t.Fatalf("Meta: %+v", m)
+		}
+		if !f.Meta["legacy"].Deprecated {
+			t.Fatalf("Meta[legacy]: %+v", f.Meta["legacy"])
+		}
+		if _, ok := f.Meta["checkout.title"]; ok {
+			t.Fatal("plain string message should not have meta")
+		}
+		if f.Messages["nested.text"] != "not a message object" || f.Messages["nested.other"] == "" {
+			t.Fatalf("Messages: %v", f.Messages)
+		}
+	})
+	t.Run("MessageObject_JSON", func(t *testing.T) {
+		src := `{"language": "en", "version": 2, "messages": {"btn": {"text": "OK", "maxLength": 8}}}`
+		f, err := ParseLocaleFile("en.json", strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("ParseLocaleFile: %v", err)
+		}
+		if f.Messages["btn"] != "OK" || f.Meta["btn"].MaxLength != 8 {
+			t.Fatalf("Messages: %v, Meta: %v", f.Messages, f.Meta)
+		}
+	})
+	t.Run("MessageObject_InvalidField", func(t *testing.T) {
+		src := "language: en\nversion: 2\nmessages:\n  btn:\n    text: OK\n    maxLength: many\n"
+		if _, err := ParseLocaleFile("en.yaml", strings.NewReader(src)); err == nil {
+			t.Fatal("expected error for invalid maxLength")
+		}
+	})
+	t.Run("MessageObject_Unversioned", func(t *testing.T) {
+		// 没有声明 version 的文件中 text/description 只是普通的嵌套 key
+		src := "language: en\nmessages:\n  foo:\n    text: Body\n    description: Summary\n"
+		f, err := ParseLocaleFile("en.yaml", strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("ParseLocaleFile: %v", err)
+		}
+		if f.Messages["foo.text"] != "Body" || f.Messages["foo.description"] != "Summary" || len(f.Meta) != 0 {
+			t.Fatalf("Messages: %v, Meta: %v", f.Messages, f.Meta)
+		}
+	})
+	t.Run("MessageObject_UnsupportedVersion", func(t *testing.T) {
+		src := "language: en\nversion: 3\nmessages:\n  foo: bar\n"
+		if _, err := ParseLocaleFile("en.yaml", strings.NewReader(src)); err == nil {
+			t.Fatal("expected error for unsupported version")
+		}
+	})
+}
+
+func TestBundle_Meta(t *testing.T) {
+	bundle := New(Config{})
+	src := "language: en\nnamespace: shop\nversion: 2\nmessages:\n  btn:\n    text: OK\n    description: confirm\n"
+	if err := bundle.LoadReader("en.yaml", strings.NewReader(src)); err != nil {
+		t.Fatalf("LoadReader: %v", err)
+	}
+	m, ok := bundle.Meta("en", "shop:btn")
+	if !ok || m.Description != "confirm" {
+		t.Fatalf("Meta: %+v, %v", m, ok)
+	}
+	if got := bundle.Locale("en").T("shop:btn", nil); got != "OK" {
+		t.Fatalf("T: %q", got)
+	}
+
+	clone := mustClone(t, bundle)
+	bundle.RemoveMessages("en", "shop:btn")
+	if _, ok := bundle.Meta("en", "shop:btn"); ok {
+		t.Fatal("meta should be removed with the message")
+	}
+	if _, ok := clone.Meta("en", "shop:btn"); !ok {
+		t.Fatal("clone should keep its own meta")
+	}
+}
@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"io/fs"
+	"sort"
+)
+
+// Overlay 是叠加在 Bundle 之上的覆盖层，用于租户/品牌定制：
+// 覆盖层只保存被覆盖的少量 key，查找时每个语言先查覆盖层，再查共享的 Bundle。
+//
+//	tenant := bundle.Overlay("tenantA")
+//	tenant.LoadDir("./overlays/tenantA")
+//	tenant.Locale("en").T("product.name", nil)
+type Overlay struct {
+	name  string
+	base  *Bundle
+	layer *Bundle
+}
+
+// Overlay 返回名为 name 的覆盖层，不存在时创建
+// 覆盖层使用与 Bundle 相同的 Config（冲突策略等），但总是使用独立的内存 MemoryStore
+func (b *Bundle) Overlay(name string) *Overlay {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ov, ok := b.overlays[name]; ok {
+		return ov
+	}
+	if b.overlays == nil {
+		b.overlays = make(map[string]*Overlay)
+	}
+	cfg := b.config
+	cfg.Store = nil
+	ov := &Overlay{
+		name:  name,
+		base:  b,
+		layer: New(cfg),
+	}
+	b.overlays[name] = ov
+	return ov
+}
+
+// Overlays 返回所有覆盖层的名称
+func (b *Bundle) Overlays() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	names := make([]string, 0, len(b.overlays))
+	for name := range b.overlays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RemoveOverlay 删除覆盖层，已经创建的 Locale 仍然可以使用
+func (b *Bundle) RemoveOverlay(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.overlays, name)
+}
+
+// Name 返回覆盖层名称
+func (o *Overlay) Name() string {
+	return o.name
+}
+
+// Base 返回覆盖层所在的 Bundle
+func (o *Overlay) Base() *Bundle {
+	return o.base
+}
+
+// Locale 返回查找时优先使用覆盖层的 Locale
+func (o *Overlay) Locale(lang string) *Locale {
+	loc := o.base.Locale(lang)
+	loc.overlay = o.layer
+	return loc
+}
+
+// RegisterMessages 向覆盖层注册翻译
+func (o *Overlay) RegisterMessages(lang string, msgs map[string]string) error {
+	return o.layer.RegisterMessages(lang, msgs)
+}
+
+// ReplaceMessages 整体替换覆盖层中某个语言的翻译
+func (o *Overlay) ReplaceMessages(lang string, msgs map[string]string) error {
+	return o.layer.ReplaceMessages(lang, msgs)
+}
+
+// RemoveMessages 从覆盖层删除若干 key，之后这些 key 回退到 Bundle 中的翻译
+func (o *Overlay) RemoveMessages(lang string, keys ...string) error {
+	return o.layer.RemoveMessages(lang, keys...)
+}
+
+// LoadFS 从 fsys 中加载覆盖层的翻译文件
+func (o *Overlay) LoadFS(fsys fs.FS, root string) error {
+	return o.layer.LoadFS(fsys, root)
+}
+
+// LoadNamespaceFS 从 fsys 中加载覆盖层的翻译文件，目录下的文件默认归入命名空间 ns
+func (o *Overlay) LoadNamespaceFS(ns string, fsys fs.FS, root string) error {
+	return o.layer.LoadNamespaceFS(ns, fsys, root)
+}
+
+// LoadDir 从目录中加载覆盖层的翻译文件
+func (o *Overlay) LoadDir(dir string) error {
+	return o.layer.LoadDir(dir)
+}
+
+// Source 返回 key 的定义位置，先查覆盖层再查 Bundle
+func (o *Overlay) Source(lang, key string) (Source, bool) {
+	if src, ok := o.layer.Source(lang, key); ok {
+		return src, ok
+	}
+	return o.base.Source(lang, key)
+}
+
+// Meta 返回 key 的附加信息，先查覆盖层再查 Bundle
+func (o *Overlay) Meta(lang, key string) (MessageMeta, bool) {
+	if m, ok := o.layer.Meta(lang, key); ok {
+		return m, ok
+	}
+	return o.base.Meta(lang, key)
+}
@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestBundle_Overlay(t *testing.T) {
+	bundle := New(Config{DefaultLang: "en"})
+	bundle.RegisterMessages("en", map[string]string{
+		"product.name":  "Acme Cloud",
+		"support.email": "help@acme.example",
+		"greeting":      "Welcome to {product}",
+	})
+	bundle.RegisterMessages("de", map[string]string{
+		"greeting": "Willkommen bei {product}",
+	})
+
+	tenant := bundle.Overlay("tenantA")
+	if bundle.Overlay("tenantA") != tenant {
+		t.Fatal("Overlay should return the existing overlay")
+	}
+	fsys := fstest.MapFS{
+		"en.yaml": {Data: []byte("language: en\nmessages:\n  product.name: Globex Cloud\n")},
+	}
+	if err := tenant.LoadFS(fsys, "."); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	tenant.RegisterMessages("de", map[string]string{"support.email": "hilfe@globex.example"})
+
+	loc := tenant.Locale("de")
+	cases := map[string]string{
+		"greeting":      "Willkommen bei {product}",
+		"product.name":  "Globex Cloud",
+		"support.email": "hilfe@globex.example",
+	}
+	for key, want := range cases {
+		if got := loc.T(key, nil); got != want {
+			t.Fatalf("T(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	// base Bundle 不受覆盖层影响
+	if got := bundle.Locale("en").T("product.name", nil); got != "Acme Cloud" {
+		t.Fatalf("base T: %q", got)
+	}
+	if src, ok := tenant.Source("en", "product.name"); !ok || src.File != "en.yaml" {
+		t.Fatalf("Source: %v, %v", src, ok)
+	}
+
+	tenant.RemoveMessages("en", "product.name")
+	if got := tenant.Locale("en").T("product.name", nil); got != "Acme Cloud" {
+		t.Fatalf("removed override should fall back to base, got %q", got)
+	}
+
+	clone := mustClone(t, bundle)
+	tenant.RegisterMessages("en", map[string]string{"product.name": "Initech"})
+	if got := clone.Overlay("tenantA").Locale("en").T("product.name", nil); got != "Acme Cloud" {
+		t.Fatalf("cloned overlay should be independent, got %q", got)
+	}
+	if names := bundle.Overlays(); len(names) != 1 || names[0] != "tenantA" {
+		t.Fatalf("Overlays: %v", names)
+	}
+	bundle.RemoveOverlay("tenantA")
+	if names := bundle.Overlays(); len(names) != 0 {
+		t.Fatalf("Overlays: %v", names)
+	}
+}
+
+func TestBundle_OverlayCloneConcurrent(t *testing.T) {
+	bundle := New(Config{})
+	bundle.RegisterMessages("en", map[string]string{"hello": "Hello"})
+	tenant := bundle.Overlay("tenant")
+	tenant.RegisterMessages("en", map[string]string{"hello": "Howdy"})
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				tenant.Locale("en").T("hello", nil)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				bundle.Clone()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				tenant.RegisterMessages("en", map[string]string{"bye": "Bye"})
+				bundle.RegisterMessages("en", map[string]string{"bye": "Bye"})
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("deadlock between overlay lookups and Clone")
+	}
+}
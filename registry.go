@@ -35,7 +35,69 @@ func applyRegisteredFormatter(v any, name, arg string) (any, error) {
 }
 
 ///////////////////////////////////////////////////////////////////////////////
-// DEFAULT FORMATTERS REGISTERED AT INIT
+// LOADER REGISTRY
+///////////////////////////////////////////////////////////////////////////////
+
+var loaderRegistry = map[string]Loader{}
+var loaderMutex sync.RWMutex
+
+// Loader turns the raw bytes of a locale file into language, namespace and messages.
+// Path and the required `language` check are handled by the caller,
+// so a Loader only needs to care about its own format.
+type Loader interface {
+	Load(data []byte) (*LocaleFile, error)
+}
+
+// HeaderLoader is an optional interface for Loaders that can decode only the
+// header fields (language, namespace, extends, include) without the messages.
+// Lazy loading uses it to build its index; Loaders without it are fully parsed.
+type HeaderLoader interface {
+	LoadHeader(data []byte) (*LocaleFile, error)
+}
+
+// LoaderFunc adapts an ordinary function to the Loader interface.
+type LoaderFunc func(data []byte) (*LocaleFile, error)
+
+// Load calls f(data).
+func (f LoaderFunc) Load(data []byte) (*LocaleFile, error) {
+	return f(data)
+}
+
+// RegisterLoader registers a Loader for a file extension, e.g. ".toml".
+// Both Bundle loading and i18nlint dispatch through this registry;
+// registering an existing extension replaces the previous Loader.
+func RegisterLoader(ext string, l Loader) {
+	loaderMutex.Lock()
+	defer loaderMutex.Unlock()
+	loaderRegistry[normalizeExt(ext)] = l
+}
+
+// UnregisterLoader removes the Loader registered for ext, including built-in ones.
+// Files with that extension are ignored afterwards.
+func UnregisterLoader(ext string) {
+	loaderMutex.Lock()
+	defer loaderMutex.Unlock()
+	delete(loaderRegistry, normalizeExt(ext))
+}
+
+// lookupLoader finds the Loader registered for ext.
+func lookupLoader(ext string) (Loader, bool) {
+	loaderMutex.RLock()
+	defer loaderMutex.RUnlock()
+	l, ok := loaderRegistry[normalizeExt(ext)]
+	return l, ok
+}
+
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(ext)
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// DEFAULTS REGISTERED AT INIT
 ///////////////////////////////////////////////////////////////////////////////
 
 func init() {
@@ -59,4 +121,9 @@ func init() {
 	RegisterFormatter("date", func(v any, arg string) (any, error) {
 		return formatDate(v, arg)
 	})
+
+	// register built-in loaders
+	RegisterLoader(".yaml", yamlLoader{})
+	RegisterLoader(".yml", yamlLoader{})
+	RegisterLoader(".json", jsonLoader{})
 }
@@ -0,0 +1,309 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteOptions 远程翻译源的配置
+type RemoteOptions struct {
+	// Languages 需要拉取的语言，每个语言请求一次 URL 模板
+	Languages []string
+	// Interval 轮询间隔，默认 1 分钟
+	Interval time.Duration
+	// Client 发起请求的 HTTP 客户端，默认 http.DefaultClient
+	Client *http.Client
+}
+
+// remoteDoc 某个语言最近一次成功拉取的翻译文件
+type remoteDoc struct {
+	file         *LocaleFile
+	etag         string
+	lastModified string
+	hash         [sha256.Size]byte
+}
+
+// RemoteWatcher 定期通过 HTTP 拉取各语言的翻译文件，
+// 请求带上 If-None-Match / If-Modified-Since，服务端返回 304 时不重新解析。
+// 文件内容与本地文件走同一条解析路径（按 URL 的扩展名或 Content-Type 选择 Loader），
+// 有语言发生变化时重新构建一份新的翻译并原子替换到 Bundle 中。
+// 某个语言拉取或解析失败时继续使用它上一次成功拉取的内容。
+// 配置了签名校验（Config.PublicKey）时，每次内容变化都会拉取 URL 加 SignatureExt 的签名并校验，
+// 签名中的文件名为 URL 路径的最后一段（例如 .../catalogs/en.yaml 为 "en.yaml"）。
+//
+// 与 Watcher 相同，替换是整体的：Bundle 中的翻译以远程内容为准。
+type RemoteWatcher struct {
+	bundle   *Bundle
+	url      string
+	langs    []string
+	client   *http.Client
+	interval time.Duration
+
+	refreshMu sync.Mutex // 保证同一时刻只有一次拉取
+	docs      map[string]*remoteDoc
+
+	mu       sync.Mutex
+	onReload []func(err error)
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// WatchRemote 拉取 urlTemplate 指向的各语言翻译文件并替换 Bundle 的翻译，成功后启动后台轮询。
+// urlTemplate 中的 {lang} 会被替换为语言，例如 https://i18n.example.com/catalogs/{lang}.yaml。
+// 首次拉取任一语言失败时返回错误；使用完毕后需要调用 Close 停止轮询。
+// 与 WatchFS 相同，使用自定义 Store 的 Bundle 返回 ErrCustomStore。
+func (b *Bundle) WatchRemote(urlTemplate string, opts RemoteOptions) (*RemoteWatcher, error) {
+	if b.config.Store != nil {
+		return nil, ErrCustomStore
+	}
+	if !strings.Contains(urlTemplate, "{lang}") {
+		return nil, fmt.Errorf("remote url %q missing {lang} placeholder", urlTemplate)
+	}
+	if len(opts.Languages) == 0 {
+		return nil, errors.New("remote source requires at least one language")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &RemoteWatcher{
+		bundle:   b,
+		url:      urlTemplate,
+		langs:    append([]string(nil), opts.Languages...),
+		client:   opts.Client,
+		interval: opts.Interval,
+		docs:     make(map[string]*remoteDoc),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	if _, err := w.refresh(); err != nil {
+		cancel()
+		return nil, err
+	}
+	go w.loop()
+	return w, nil
+}
+
+// OnReload 注册拉取后的回调，err 为 nil 表示有语言更新成功，
+// 否则表示部分语言拉取失败，这些语言仍在使用上一次成功拉取的翻译
+func (w *RemoteWatcher) OnReload(fn func(err error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onReload = append(w.onReload, fn)
+}
+
+// Reload 立即拉取一次所有语言
+func (w *RemoteWatcher) Reload() error {
+	_, err := w.refresh()
+	w.notify(err)
+	return err
+}
+
+// Close 停止轮询并取消进行中的请求
+func (w *RemoteWatcher) Close() error {
+	w.stopOnce.Do(w.cancel)
+	<-w.done
+	return nil
+}
+
+func (w *RemoteWatcher) loop() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			// 没有变化也没有错误时不回调
+			if changed, err := w.refresh(); changed || err != nil {
+				w.notify(err)
+			}
+		}
+	}
+}
+
+// refresh 拉取所有语言，有语言发生变化时重新构建翻译并替换
+func (w *RemoteWatcher) refresh() (bool, error) {
+	w.refreshMu.Lock()
+	defer w.refreshMu.Unlock()
+
+	var errs []error
+	changed := false
+	for _, lang := range w.langs {
+		ok, err := w.fetch(lang)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		changed = changed || ok
+	}
+	if changed {
+		if err := w.rebuild(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+// fetch 拉取某个语言，内容发生变化时返回 true
+func (w *RemoteWatcher) fetch(lang string) (bool, error) {
+	u := strings.ReplaceAll(w.url, "{lang}", url.PathEscape(lang))
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, err
+	}
+	prev := w.docs[lang]
+	if prev != nil {
+		if prev.etag != "" {
+			req.Header.Set("If-None-Match", prev.etag)
+		}
+		if prev.lastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.lastModified)
+		}
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && prev != nil {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("GET %s: %w", u, err)
+	}
+
+	doc := &remoteDoc{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		hash:         sha256.Sum256(data),
+	}
+	if prev != nil && prev.hash == doc.hash {
+		// 服务端不支持条件请求时，内容未变化也不重新构建
+		doc.file = prev.file
+		w.docs[lang] = doc
+		return false, nil
+	}
+
+	if cfg := w.bundle.config; cfg.signatureEnabled() {
+		sig, err := w.fetchSignature(u + SignatureExt)
+		if err != nil {
+			return false, err
+		}
+		if err := cfg.verifySignature(u, remotePath(u), data, sig); err != nil {
+			return false, err
+		}
+	}
+
+	f, err := parseLocaleFile(remoteFileName(u, resp.Header.Get("Content-Type")), bytes.NewReader(data))
+	if err != nil {
+		return false, fmt.Errorf("load %s: %w", u, err)
+	}
+	f.setPath(u)
+	if f.Language == "" {
+		f.Language = lang
+	}
+	if f.Language != lang {
+		return false, fmt.Errorf("load %s: language %q does not match requested %q", u, f.Language, lang)
+	}
+	if f.Extends != "" || len(f.Include) > 0 {
+		return false, fmt.Errorf("load %s: extends/include are not supported for remote catalogs", u)
+	}
+	doc.file = f
+	w.docs[lang] = doc
+	return true, nil
+}
+
+// fetchSignature 拉取 detached 签名，服务端返回 404 时视为没有签名
+func (w *RemoteWatcher) fetchSignature(u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+}
+
+// rebuild 用各语言最近一次成功拉取的内容构建新的 Bundle，成功后整体替换
+func (w *RemoteWatcher) rebuild() error {
+	fresh := New(w.bundle.config)
+	var files []*LocaleFile
+	for _, lang := range w.langs {
+		if doc, ok := w.docs[lang]; ok {
+			files = append(files, doc.file)
+		}
+	}
+	if err := fresh.registerFiles(files); err != nil {
+		return err
+	}
+	w.bundle.swap(fresh)
+	return nil
+}
+
+func (w *RemoteWatcher) notify(err error) {
+	w.mu.Lock()
+	fns := append([]func(error){}, w.onReload...)
+	w.mu.Unlock()
+	for _, fn := range fns {
+		fn(err)
+	}
+}
+
+// remotePath 返回 URL 的路径部分，签名覆盖其最后一段（见 SignLocaleFile）
+func remotePath(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		return u.Path
+	}
+	return rawURL
+}
+
+// remoteFileName 返回用于选择 Loader 的文件名：
+// URL 路径带有已注册的扩展名时直接使用，否则按 Content-Type 推断
+func remoteFileName(rawURL, contentType string) string {
+	name := remotePath(rawURL)
+	if isLocaleFile(name) {
+		return name
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasSuffix(mediaType, "json"):
+		return name + ".json"
+	case strings.HasSuffix(mediaType, "yaml"):
+		return name + ".yaml"
+	}
+	return name
+}
@@ -0,0 +1,186 @@
+package i18n
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// catalogServer 按语言返回翻译文件，支持 ETag 条件请求
+type catalogServer struct {
+	mu          sync.Mutex
+	docs        map[string]string
+	versions    map[string]int
+	fail        bool
+	notModified int
+}
+
+func (s *catalogServer) set(lang, doc string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs[lang] = doc
+	s.versions[lang]++
+}
+
+func (s *catalogServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		http.Error(w, "boom", http.StatusInternalServerError)
+		return
+	}
+	lang := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/catalogs/"), ".yaml")
+	doc, ok := s.docs[lang]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	etag := fmt.Sprintf(`"%s-%d"`, lang, s.versions[lang])
+	if r.Header.Get("If-None-Match") == etag {
+		s.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	w.Write([]byte(doc))
+}
+
+func TestBundle_WatchRemote(t *testing.T) {
+	cs := &catalogServer{docs: map[string]string{}, versions: map[string]int{}}
+	cs.set("en", "language: en\nmessages:\n  hello: Hello\n")
+	cs.set("zh-CN", "messages:\n  hello: 你好\n")
+	srv := httptest.NewServer(cs)
+	defer srv.Close()
+
+	bundle := New(Config{DefaultLang: "en"})
+	w, err := bundle.WatchRemote(srv.URL+"/catalogs/{lang}.yaml", RemoteOptions{
+		Languages: []string{"en", "zh-CN"},
+		Interval:  time.Hour,
+		Client:    srv.Client(),
+	})
+	if err != nil {
+		t.Fatalf("WatchRemote: %v", err)
+	}
+	defer w.Close()
+
+	if got := bundle.Locale("zh-CN").T("hello", nil); got != "你好" {
+		t.Fatalf("T: %q", got)
+	}
+	if src, ok := bundle.Source("en", "hello"); !ok || src.File != srv.URL+"/catalogs/en.yaml" || src.Line != 3 {
+		t.Fatalf("Source: %v, %v", src, ok)
+	}
+
+	t.Run("Remote_NotModified", func(t *testing.T) {
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Reload: %v", err)
+		}
+		if cs.notModified != 2 {
+			t.Fatalf("notModified = %d, want 2", cs.notModified)
+		}
+	})
+
+	t.Run("Remote_Changed", func(t *testing.T) {
+		cs.set("en", "language: en\nmessages:\n  hello: Hi\n")
+		if err := w.Reload(); err != nil {
+			t.Fatalf("Reload: %v", err)
+		}
+		if got := bundle.Locale("en").T("hello", nil); got != "Hi" {
+			t.Fatalf("T after reload: %q", got)
+		}
+	})
+
+	t.Run("Remote_KeepLastGood", func(t *testing.T) {
+		cs.mu.Lock()
+		cs.fail = true
+		cs.mu.Unlock()
+		if err := w.Reload(); err == nil {
+			t.Fatal("expected error")
+		}
+		if got := bundle.Locale("en").T("hello", nil); got != "Hi" {
+			t.Fatalf("T after failed reload: %q", got)
+		}
+
+		cs.mu.Lock()
+		cs.fail = false
+		cs.mu.Unlock()
+		cs.set("zh-CN", "language: en\nmessages:\n  hello: oops\n")
+		err := w.Reload()
+		if err == nil || !strings.Contains(err.Error(), "does not match") {
+			t.Fatalf("expected language mismatch, got %v", err)
+		}
+		if got := bundle.Locale("zh-CN").T("hello", nil); got != "你好" {
+			t.Fatalf("zh-CN after bad document: %q", got)
+		}
+	})
+}
+
+func TestBundle_WatchRemote_InitialError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	bundle := New(Config{})
+	_, err := bundle.WatchRemote(srv.URL+"/{lang}.json", RemoteOptions{Languages: []string{"en"}, Client: srv.Client()})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := bundle.WatchRemote(srv.URL+"/en.json", RemoteOptions{Languages: []string{"en"}}); err == nil {
+		t.Fatal("expected missing {lang} error")
+	}
+
+	// 严格模式下没有签名的远程翻译被拒绝
+	cs := &catalogServer{docs: map[string]string{}, versions: map[string]int{}}
+	cs.set("en", "language: en\nmessages:\n  hello: Hello\n")
+	signed := httptest.NewServer(cs)
+	defer signed.Close()
+	strict := New(Config{RequireSignature: true})
+	_, err = strict.WatchRemote(signed.URL+"/catalogs/{lang}.yaml", RemoteOptions{Languages: []string{"en"}, Client: signed.Client()})
+	if !errors.Is(err, ErrUnsigned) {
+		t.Fatalf("expected ErrUnsigned, got %v", err)
+	}
+}
+
+func TestBundle_WatchRemote_Signed(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	en := []byte("language: en\nmessages:\n  hello: Hello\n")
+	files := map[string][]byte{
+		"/catalogs/en.yaml":     en,
+		"/catalogs/en.yaml.sig": SignLocaleFile(priv, "en.yaml", en),
+		// 签名时使用了其他文件名
+		"/renamed/en.yaml":     en,
+		"/renamed/en.yaml.sig": SignLocaleFile(priv, "de.yaml", en),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	opts := RemoteOptions{Languages: []string{"en"}, Interval: time.Hour, Client: srv.Client()}
+	bundle := New(Config{PublicKey: pub, RequireSignature: true})
+	w, err := bundle.WatchRemote(srv.URL+"/catalogs/{lang}.yaml", opts)
+	if err != nil {
+		t.Fatalf("WatchRemote: %v", err)
+	}
+	defer w.Close()
+	if got := bundle.Locale("en").T("hello", nil); got != "Hello" {
+		t.Fatalf("T: %q", got)
+	}
+
+	_, err = New(Config{PublicKey: pub}).WatchRemote(srv.URL+"/renamed/{lang}.yaml", opts)
+	if !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("expected ErrBadSignature, got %v", err)
+	}
+}
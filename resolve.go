@@ -0,0 +1,190 @@
+package i18n
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// STRUCT / METHOD RESOLUTION
+///////////////////////////////////////////////////////////////////////////////
+
+// typeMembers holds the placeholder-visible members of one type, keyed by
+// lower-cased name. It is built once per type and cached in typeCache.
+type typeMembers struct {
+	fields  map[string][]int // field index path, including promoted fields
+	methods map[string]int   // method index in the pointer method set
+}
+
+var typeCache sync.Map // reflect.Type -> *typeMembers
+
+var (
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// membersOf returns the cached members of t (a non-pointer type).
+//
+// Fields match by their `i18n:"name"` tag, or by name (case-insensitive) when
+// untagged; `i18n:"-"` hides a field. Exported fields promoted from embedded
+// structs are included. Unexported fields are always ignored, even when they
+// carry an `i18n` tag, since reflection cannot read them; expose such data
+// through a getter method instead. Methods match by name (case-insensitive) when they
+// look like getters: no arguments, and a result that is a basic kind, a
+// fmt.Stringer or an encoding.TextMarshaler, optionally followed by an error.
+// Methods such as Close() error are therefore never callable from a template.
+func membersOf(t reflect.Type) *typeMembers {
+	if m, ok := typeCache.Load(t); ok {
+		return m.(*typeMembers)
+	}
+
+	m := &typeMembers{
+		fields:  map[string][]int{},
+		methods: map[string]int{},
+	}
+	if t.Kind() == reflect.Struct {
+		// a tagged name beats a plain field name; otherwise the shallower field wins
+		tagged := map[string]bool{}
+		for _, f := range reflect.VisibleFields(t) {
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Name
+			tag, hasTag := f.Tag.Lookup("i18n")
+			if tag, _, _ = strings.Cut(tag, ","); tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+			key := strings.ToLower(name)
+			isTagged := hasTag && tag != ""
+			if prev, ok := m.fields[key]; ok {
+				if tagged[key] && !isTagged || tagged[key] == isTagged && len(prev) <= len(f.Index) {
+					continue
+				}
+			}
+			m.fields[key] = f.Index
+			tagged[key] = isTagged
+		}
+	}
+
+	pt := reflect.PointerTo(t)
+	for i := 0; i < pt.NumMethod(); i++ {
+		mt := pt.Method(i)
+		ft := mt.Type // includes the receiver
+		if ft.NumIn() != 1 {
+			continue
+		}
+		if ft.NumOut() == 0 || !isGetterResult(ft.Out(0)) {
+			continue
+		}
+		if ft.NumOut() == 1 || ft.NumOut() == 2 && ft.Out(1) == errorType {
+			m.methods[strings.ToLower(mt.Name)] = i
+		}
+	}
+
+	actual, _ := typeCache.LoadOrStore(t, m)
+	return actual.(*typeMembers)
+}
+
+// isGetterResult reports whether a method returning t may be called from a
+// placeholder.
+func isGetterResult(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return t != errorType && (t.Implements(stringerType) || t.Implements(textMarshalerType))
+}
+
+// recoverPanic turns a panic in user code (a getter method, String or
+// MarshalText) into an error, so that it surfaces as a render error instead
+// of escaping T. Use it as `defer recoverPanic(&err, what)`.
+func recoverPanic(err *error, what string) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%s panicked: %v", what, r)
+	}
+}
+
+// resolveMember resolves one path segment against a struct (or pointer to one),
+// a map with string keys, or any value with a matching getter method.
+// An error returned by the getter, or a panic in it, is reported as an error.
+func resolveMember(current any, seg string) (_ any, _ bool, err error) {
+	v := reflect.ValueOf(current)
+	if !v.IsValid() {
+		return nil, false, nil
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, false, nil
+	}
+
+	base := v
+	if base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+	if base.Kind() == reflect.Map && base.Type().Key().Kind() == reflect.String {
+		mv := base.MapIndex(reflect.ValueOf(seg).Convert(base.Type().Key()))
+		if !mv.IsValid() {
+			return nil, false, nil
+		}
+		return mv.Interface(), true, nil
+	}
+
+	members := membersOf(base.Type())
+	key := strings.ToLower(seg)
+	if index, ok := members.fields[key]; ok {
+		f, err := base.FieldByIndexErr(index)
+		if err != nil || !f.CanInterface() {
+			// nil embedded pointer
+			return nil, false, nil
+		}
+		return f.Interface(), true, nil
+	}
+	if i, ok := members.methods[key]; ok {
+		ptr := v
+		if ptr.Kind() != reflect.Pointer {
+			// make an addressable copy so pointer-receiver methods are callable
+			ptr = reflect.New(base.Type())
+			ptr.Elem().Set(base)
+		}
+		name := fmt.Sprintf("method %s.%s", base.Type(), ptr.Type().Method(i).Name)
+		defer recoverPanic(&err, name)
+		out := ptr.Method(i).Call(nil)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, false, fmt.Errorf("%s: %w", name, out[1].Interface().(error))
+		}
+		return out[0].Interface(), true, nil
+	}
+	return nil, false, nil
+}
+
+// stringify converts the final placeholder value to text, honouring
+// fmt.Stringer and encoding.TextMarshaler. A panic in either is reported as
+// an error.
+func stringify(v any) (_ string, err error) {
+	defer recoverPanic(&err, fmt.Sprintf("formatting %T", v))
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return fmt.Sprint(v), nil
+	}
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case fmt.Stringer:
+		return t.String(), nil
+	case encoding.TextMarshaler:
+		b, err := t.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return fmt.Sprint(v), nil
+}
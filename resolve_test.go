@@ -0,0 +1,142 @@
+package i18n
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type resolveProfile struct {
+	Bio string
+}
+
+type resolveAccount struct {
+	resolveProfile
+	Name     string `i18n:"nick"`
+	Email    string
+	Password string `i18n:"-"`
+	first    string `i18n:"given"` // 未导出，标签不生效
+	last     string
+	level    resolveLevel
+	IP       net.IP
+}
+
+func (a resolveAccount) DisplayName() string { return a.first + " " + a.last }
+
+var errResolveNoName = errors.New("no name")
+
+func (a *resolveAccount) Initials() (string, error) {
+	if a.first == "" {
+		return "", errResolveNoName
+	}
+	return a.first[:1] + a.last[:1], nil
+}
+
+func (a resolveAccount) Level() resolveLevel { return a.level }
+
+type resolveLevel int
+
+func (l resolveLevel) String() string { return [...]string{"bronze", "silver", "gold"}[l] }
+
+type resolveColor struct{ r, g, b uint8 }
+
+func (c resolveColor) MarshalText() ([]byte, error) {
+	return []byte{'#', "0123456789abcdef"[c.r>>4], "0123456789abcdef"[c.r&15]}, nil
+}
+
+func TestStructArgs(t *testing.T) {
+	acc := resolveAccount{
+		resolveProfile: resolveProfile{Bio: "gopher"},
+		Name:           "ann",
+		Email:          "ann@example.com",
+		Password:       "secret",
+		first:          "Ann",
+		last:           "Lee",
+		level:          2,
+		IP:             net.IPv4(10, 0, 0, 1),
+	}
+	args := map[string]any{"user": acc, "ptr": &acc, "color": resolveColor{r: 0xab}}
+
+	cases := map[string]string{
+		"{user.nick}":        "ann",
+		"{user.EMAIL}":       "ann@example.com",
+		"{user.bio}":         "gopher",
+		"{user.DisplayName}": "Ann Lee",
+		"{user.initials}":    "AL",
+		"{ptr.initials}":     "AL",
+		"{user.level}":       "gold",
+		"{user.ip}":          "10.0.0.1",
+		"{color}":            "#ab",
+	}
+	for tpl, want := range cases {
+		got, err := RenderTemplate(tpl, args)
+		if err != nil || got != want {
+			t.Fatalf("RenderTemplate(%q) = %q, %v; want %q", tpl, got, err, want)
+		}
+	}
+
+	for _, tpl := range []string{"{user.name}", "{user.password}", "{user.first}", "{user.given}"} {
+		if _, err := RenderTemplate(tpl, args); err == nil {
+			t.Fatalf("RenderTemplate(%q): expected error", tpl)
+		}
+	}
+
+	// getter 返回的错误原样传出
+	args["anon"] = resolveAccount{}
+	if _, err := RenderTemplate("{anon.initials}", args); !errors.Is(err, errResolveNoName) {
+		t.Fatalf("expected the getter error, got %v", err)
+	}
+	bundle := New(Config{DefaultLang: "en"})
+	bundle.RegisterMessages("en", map[string]string{"initials": "{anon.initials}"})
+	var renderErr *RenderError
+	if _, err := bundle.Locale("en").Translate("initials", args); !errors.As(err, &renderErr) || !errors.Is(err, errResolveNoName) {
+		t.Fatalf("expected RenderError wrapping the getter error, got %v", err)
+	}
+
+	if m, ok := typeCache.Load(reflect.TypeOf(acc)); !ok || m.(*typeMembers).fields["nick"] == nil {
+		t.Fatal("type members should be cached")
+	}
+}
+
+type resolveConn struct{ closed *bool }
+
+func (c resolveConn) Close() error { *c.closed = true; return nil }
+
+func (c resolveConn) Profile() resolveProfile { return resolveProfile{Bio: "conn"} }
+
+func (c resolveConn) Boom() string { panic("boom") }
+
+type resolvePanicky struct{}
+
+func (resolvePanicky) String() string { panic("stringer boom") }
+
+func TestStructArgs_Unsafe(t *testing.T) {
+	closed := false
+	args := map[string]any{"db": resolveConn{closed: &closed}, "bad": resolvePanicky{}}
+
+	// 不是 getter 形状的方法不能调用
+	for _, tpl := range []string{"{db.Close}", "{db.profile.bio}"} {
+		if _, err := RenderTemplate(tpl, args); err == nil {
+			t.Fatalf("RenderTemplate(%q): expected error", tpl)
+		}
+	}
+	if closed {
+		t.Fatal("Close must not be called from a template")
+	}
+
+	// panic 转为渲染错误
+	for _, tpl := range []string{"{db.boom}", "{bad}"} {
+		if _, err := RenderTemplate(tpl, args); err == nil || !strings.Contains(err.Error(), "panicked") {
+			t.Fatalf("RenderTemplate(%q): expected panic error, got %v", tpl, err)
+		}
+	}
+
+	bundle := New(Config{DefaultLang: "en"})
+	bundle.RegisterMessages("en", map[string]string{"boom": "Hi {db.boom}"})
+	var renderErr *RenderError
+	if _, err := bundle.Locale("en").Translate("boom", args); !errors.As(err, &renderErr) {
+		t.Fatalf("expected RenderError, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// SignatureExt detached 签名文件的扩展名：en.yaml 的签名文件为 en.yaml.sig
+const SignatureExt = ".sig"
+
+var (
+	// ErrUnsigned 严格模式下翻译文件没有签名文件
+	ErrUnsigned = errors.New("i18n: locale file is not signed")
+	// ErrBadSignature 签名与文件内容不匹配（文件被修改或使用了其他密钥签名）
+	ErrBadSignature = errors.New("i18n: invalid locale file signature")
+)
+
+// SignLocaleFile 用 ed25519 私钥对翻译文件签名，返回可直接写入 .sig 文件的 base64 文本。
+// 签名同时覆盖文件名（name 的最后一段，例如 "en.yaml"）与内容：
+// 改名后的文件（例如把已签名的 de.yaml 改名为 en.yaml）无法通过校验
+func SignLocaleFile(key ed25519.PrivateKey, name string, data []byte) []byte {
+	sig := ed25519.Sign(key, signedPayload(name, data))
+	out := make([]byte, base64.StdEncoding.EncodedLen(len(sig)))
+	base64.StdEncoding.Encode(out, sig)
+	return out
+}
+
+// signedPayload 返回签名覆盖的内容：文件名的最后一段、一个 0 字节、文件内容
+func signedPayload(name string, data []byte) []byte {
+	base := path.Base(name)
+	payload := make([]byte, 0, len(base)+1+len(data))
+	payload = append(payload, base...)
+	payload = append(payload, 0)
+	return append(payload, data...)
+}
+
+// signatureEnabled Config 是否要求校验签名
+func (c Config) signatureEnabled() bool {
+	return c.PublicKey != nil || c.RequireSignature
+}
+
+// verifySignature 用 Config.PublicKey 校验文件 file 的 detached 签名，sig 为 nil 表示没有签名文件，
+// name 用于错误信息。签名文件可以是 64 字节的原始签名，也可以是它的 base64 文本
+func (c Config) verifySignature(name, file string, data, sig []byte) error {
+	if sig == nil {
+		if c.RequireSignature {
+			return fmt.Errorf("%s: %w", name, ErrUnsigned)
+		}
+		return nil
+	}
+	if len(c.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%s: no valid ed25519 public key configured", name)
+	}
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, ErrBadSignature)
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(c.PublicKey, signedPayload(file, data), sig) {
+		return fmt.Errorf("%s: %w", name, ErrBadSignature)
+	}
+	return nil
+}
+
+// verifiedFS 在配置了签名校验时包装 fsys，打开翻译文件前先校验它的签名文件
+func (b *Bundle) verifiedFS(fsys fs.FS) fs.FS {
+	if !b.config.signatureEnabled() {
+		return fsys
+	}
+	return &signedFS{FS: fsys, config: b.config}
+}
+
+// signedFS 打开翻译文件时校验同目录下的 .sig 文件，校验失败时 Open 返回错误；
+// 其他文件（目录、签名文件本身）原样打开
+type signedFS struct {
+	fs.FS
+	config Config
+}
+
+func (s *signedFS) Open(name string) (fs.File, error) {
+	if !isLocaleFile(name) {
+		return s.FS.Open(name)
+	}
+	data, err := fs.ReadFile(s.FS, name)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := fs.ReadFile(s.FS, name+SignatureExt)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err := s.config.verifySignature(name, name, data, sig); err != nil {
+		return nil, err
+	}
+	info, err := fs.Stat(s.FS, name)
+	if err != nil {
+		return nil, err
+	}
+	return &verifiedFile{Reader: bytes.NewReader(data), info: info}, nil
+}
+
+// verifiedFile 已通过校验的文件内容，避免校验后再次读取时文件被替换
+type verifiedFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *verifiedFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *verifiedFile) Close() error               { return nil }
+
+// isSignatureFile 判断 name 是否为某个翻译文件的签名文件
+func isSignatureFile(name string) bool {
+	return strings.HasSuffix(name, SignatureExt) && isLocaleFile(strings.TrimSuffix(name, SignatureExt))
+}
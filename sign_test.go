@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundle_SignedCatalogs(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	en := []byte("language: en\nmessages:\n  hello: Hello\n")
+	fr := []byte("language: fr\nmessages:\n  hello: Bonjour\n")
+
+	t.Run("Signed_OK", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"en.yaml":     {Data: en},
+			"en.yaml.sig": {Data: SignLocaleFile(priv, "en.yaml", en)},
+			// 原始 64 字节签名同样有效
+			"fr.yaml":     {Data: fr},
+			"fr.yaml.sig": {Data: ed25519.Sign(priv, signedPayload("fr.yaml", fr))},
+		}
+		bundle := New(Config{PublicKey: pub, RequireSignature: true})
+		if err := bundle.LoadFS(fsys, "."); err != nil {
+			t.Fatalf("LoadFS: %v", err)
+		}
+		if got := bundle.Locale("fr").T("hello", nil); got != "Bonjour" {
+			t.Fatalf("T: %q", got)
+		}
+	})
+
+	t.Run("Signed_Modified", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"en.yaml":     {Data: []byte("language: en\nmessages:\n  hello: Pwned\n")},
+			"en.yaml.sig": {Data: SignLocaleFile(priv, "en.yaml", en)},
+		}
+		bundle := New(Config{PublicKey: pub})
+		if err := bundle.LoadFS(fsys, "."); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("expected ErrBadSignature, got %v", err)
+		}
+		if got := bundle.Locale("en").T("hello", nil); got != "hello" {
+			t.Fatalf("modified file should not be registered, got %q", got)
+		}
+	})
+
+	t.Run("Signed_Unsigned", func(t *testing.T) {
+		fsys := fstest.MapFS{"en.yaml": {Data: en}}
+		// 非严格模式允许没有签名的文件
+		if err := New(Config{PublicKey: pub}).LoadFS(fsys, "."); err != nil {
+			t.Fatalf("LoadFS: %v", err)
+		}
+		strict := New(Config{PublicKey: pub, RequireSignature: true})
+		if err := strict.LoadFS(fsys, "."); !errors.Is(err, ErrUnsigned) {
+			t.Fatalf("expected ErrUnsigned, got %v", err)
+		}
+		if err := strict.LoadReader("en.yaml", strings.NewReader(string(en))); !errors.Is(err, ErrUnsigned) {
+			t.Fatalf("LoadReader: expected ErrUnsigned, got %v", err)
+		}
+		if err := strict.LoadBinary("locales.bin", strings.NewReader("")); !errors.Is(err, ErrUnsigned) {
+			t.Fatalf("LoadBinary: expected ErrUnsigned, got %v", err)
+		}
+		if err := strict.LoadSQL(context.Background(), &SQLSource{}); !errors.Is(err, ErrUnsigned) {
+			t.Fatalf("LoadSQL: expected ErrUnsigned, got %v", err)
+		}
+		if err := strict.RefreshSQL(context.Background(), &SQLSource{}); !errors.Is(err, ErrUnsigned) {
+			t.Fatalf("RefreshSQL: expected ErrUnsigned, got %v", err)
+		}
+	})
+
+	t.Run("Signed_Renamed", func(t *testing.T) {
+		de := []byte("language: de\nmessages:\n  hello: Hallo\n")
+		// 已签名的 de.yaml 被改名为 en.yaml
+		fsys := fstest.MapFS{
+			"en.yaml":     {Data: de},
+			"en.yaml.sig": {Data: SignLocaleFile(priv, "de.yaml", de)},
+		}
+		if err := New(Config{PublicKey: pub}).LoadFS(fsys, "."); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("expected ErrBadSignature, got %v", err)
+		}
+	})
+
+	t.Run("Signed_Lazy", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"en.yaml":     {Data: en},
+			"en.yaml.sig": {Data: SignLocaleFile(priv, "en.yaml", en)},
+		}
+		bundle := New(Config{PublicKey: pub, RequireSignature: true})
+		if err := bundle.LoadLazyFS(fsys, ".", LazyOptions{}); err != nil {
+			t.Fatalf("LoadLazyFS: %v", err)
+		}
+		// 建立索引之后文件被替换
+		fsys["en.yaml"] = &fstest.MapFile{Data: []byte("language: en\nmessages:\n  hello: Pwned\n")}
+		if err := bundle.Preload("en"); !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("expected ErrBadSignature, got %v", err)
+		}
+	})
+}
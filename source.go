@@ -0,0 +1,177 @@
+package i18n
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source 记录一个 key 定义在哪个文件的哪个位置
+// Line/Column 从 1 开始，为 0 表示 Loader 没有提供位置信息（例如 JSON 文件）
+type Source struct {
+	File   string
+	Line   int
+	Column int
+
+	origin    any  // 文件所在的 fs.FS（见 fsOrigin），同一路径出现在不同 fs 中时用于区分
+	inherited bool // 通过 extends 从祖先语言继承而来，不是本语言的定义，不参与冲突检测
+}
+
+func (s Source) String() string {
+	if s.Line == 0 {
+		return s.File
+	}
+	return fmt.Sprintf("%s:%d:%d", s.File, s.Line, s.Column)
+}
+
+// Source 返回某个语言下 key 的定义位置，key 可以带命名空间前缀
+// 通过 RegisterMessages 等方式手动注册的 key 没有位置信息
+func (b *Bundle) Source(lang, key string) (Source, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	src, ok := b.sources[lang][key]
+	src.origin, src.inherited = nil, false
+	return src, ok
+}
+
+// trackSourcesLocked 记录 msgs 中每个 key 的定义位置，调用方需持有 b.wmu 与 b.mu 写锁
+func (b *Bundle) trackSourcesLocked(f *LocaleFile, msgs map[string]string) {
+	if b.sources == nil {
+		b.sources = make(map[string]map[string]Source)
+	}
+	if b.sources[f.Language] == nil {
+		b.sources[f.Language] = make(map[string]Source, len(msgs))
+	}
+	for k := range f.Messages {
+		key := NamespacedKey(f.Namespace, k)
+		if _, ok := msgs[key]; !ok {
+			continue
+		}
+		b.sources[f.Language][key] = f.source(k)
+	}
+}
+
+// source 返回文件中 key（不带命名空间前缀）的定义位置，继承而来的翻译带有 inherited 标记
+func (f *LocaleFile) source(k string) Source {
+	src, ok := f.Sources[k]
+	if !ok {
+		src = Source{File: f.Path}
+	}
+	src.origin = f.origin
+	src.inherited = f.InheritedFrom != ""
+	return src
+}
+
+// keyError 与某个 key 相关的加载错误，Loader 可以据此补充位置信息
+type keyError struct {
+	key string
+	err error
+}
+
+func (e *keyError) Error() string { return e.err.Error() }
+func (e *keyError) Unwrap() error { return e.err }
+
+// decodeYAMLMessages 把 messages 节点解码为嵌套 map，同时记录每一级 key 的行列号
+// （key 为点分隔的完整路径）
+func decodeYAMLMessages(n *yaml.Node) (map[string]any, map[string]Source, error) {
+	sources := make(map[string]Source)
+	if n.Kind == 0 {
+		return nil, sources, nil
+	}
+	n = resolveYAMLAlias(n)
+	if n.Tag == "!!null" {
+		return nil, sources, nil
+	}
+	tree, err := decodeYAMLMapping(n, "", sources)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tree, sources, nil
+}
+
+func decodeYAMLMapping(n *yaml.Node, prefix string, sources map[string]Source) (map[string]any, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d, column %d: messages must be a mapping", n.Line, n.Column)
+	}
+	out := make(map[string]any, len(n.Content)/2)
+	// 合并键（`<<: *base`）先展开，显式定义的 key 随后覆盖合并进来的同名 key
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if isYAMLMergeKey(n.Content[i]) {
+			if err := mergeYAMLMapping(out, resolveYAMLAlias(n.Content[i+1]), prefix, sources); err != nil {
+				return nil, err
+			}
+		}
+	}
+	explicit := make(map[string]struct{}, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], resolveYAMLAlias(n.Content[i+1])
+		if isYAMLMergeKey(k) {
+			continue
+		}
+		path := k.Value
+		if prefix != "" {
+			path = prefix + "." + k.Value
+		}
+		if _, dup := explicit[k.Value]; dup {
+			return nil, fmt.Errorf("line %d, column %d: duplicate key %q", k.Line, k.Column, path)
+		}
+		explicit[k.Value] = struct{}{}
+		sources[path] = Source{Line: k.Line, Column: k.Column}
+
+		if v.Kind == yaml.MappingNode {
+			child, err := decodeYAMLMapping(v, path, sources)
+			if err != nil {
+				return nil, err
+			}
+			out[k.Value] = child
+			continue
+		}
+		var val any
+		if err := v.Decode(&val); err != nil {
+			return nil, fmt.Errorf("line %d, column %d: %w", v.Line, v.Column, err)
+		}
+		out[k.Value] = val
+	}
+	return out, nil
+}
+
+// isYAMLMergeKey 判断 k 是否为合并键：不带引号的 `<<` 或显式标注 `!!merge`
+func isYAMLMergeKey(k *yaml.Node) bool {
+	return k.Kind == yaml.ScalarNode && k.ShortTag() == "!!merge"
+}
+
+// mergeYAMLMapping 把合并键的值（映射，或映射组成的序列）合并进 out，
+// 序列中靠前的映射优先，与 YAML 合并键的语义一致
+func mergeYAMLMapping(out map[string]any, v *yaml.Node, prefix string, sources map[string]Source) error {
+	switch v.Kind {
+	case yaml.MappingNode:
+		m, err := decodeYAMLMapping(v, prefix, sources)
+		if err != nil {
+			return err
+		}
+		for k, val := range m {
+			out[k] = val
+		}
+		return nil
+	case yaml.SequenceNode:
+		for i := len(v.Content) - 1; i >= 0; i-- {
+			item := resolveYAMLAlias(v.Content[i])
+			if item.Kind != yaml.MappingNode {
+				return fmt.Errorf("line %d, column %d: merge value must be a mapping or a sequence of mappings", item.Line, item.Column)
+			}
+			if err := mergeYAMLMapping(out, item, prefix, sources); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("line %d, column %d: merge value must be a mapping or a sequence of mappings", v.Line, v.Column)
+	}
+}
+
+func resolveYAMLAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundle_Source(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/en.yaml": {Data: []byte(`language: en
+messages:
+  common.hello: "Hello"
+  user:
+    login:
+      success: "Welcome back"
+`)},
+		"locales/fr.json": {Data: []byte(`{"language": "fr", "messages": {"common.hello": "Bonjour"}}`)},
+	}
+	bundle := New(Config{})
+	if err := bundle.LoadFS(fsys, "locales"); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+
+	cases := []struct {
+		lang, key string
+		want      Source
+	}{
+		{"en", "common.hello", Source{File: "locales/en.yaml", Line: 3, Column: 3}},
+		{"en", "user.login.success", Source{File: "locales/en.yaml", Line: 6, Column: 7}},
+		{"fr", "common.hello", Source{File: "locales/fr.json"}},
+	}
+	for _, c := range cases {
+		got, ok := bundle.Source(c.lang, c.key)
+		if !ok || got != c.want {
+			t.Fatalf("Source(%q, %q) = %v, %v, want %v", c.lang, c.key, got, ok, c.want)
+		}
+	}
+
+	bundle.RegisterMessages("en", map[string]string{"common.hello": "Hi"})
+	if _, ok := bundle.Source("en", "common.hello"); ok {
+		t.Fatal("manually registered key should have no source")
+	}
+}
+
+func TestParseLocaleFile_ErrorPosition(t *testing.T) {
+	cases := map[string]string{
+		"duplicate": "language: en\nmessages:\n  user.name: a\n  user:\n    name: b\n",
+		"collision": "language: en\nmessages:\n  user: a\n  user.name: b\n",
+		"metadata":  "language: en\nversion: 2\nmessages:\n  ok: fine\n  btn:\n    text: OK\n    maxLength: many\n",
+	}
+	wants := map[string]string{
+		"duplicate": "line 5, column 5",
+		"collision": "line 3, column 3",
+		"metadata":  "line 5, column 3",
+	}
+	for name, src := range cases {
+		_, err := ParseLocaleFile("en.yaml", strings.NewReader(src))
+		if err == nil || !strings.Contains(err.Error(), wants[name]) {
+			t.Fatalf("%s: err = %v, want position %q", name, err, wants[name])
+		}
+	}
+}
+
+func TestParseLocaleFile_YAMLMergeKey(t *testing.T) {
+	src := `language: en
+messages:
+  base: &base
+    ok: OK
+    cancel: Cancel
+  extra: &extra
+    help: Help
+    cancel: Abort
+  dialog:
+    <<: *base
+    title: Dialog
+    ok: Confirm
+  wizard:
+    !!merge <<: [*extra, *base]
+`
+	f, err := ParseLocaleFile("en.yaml", strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseLocaleFile: %v", err)
+	}
+	want := map[string]string{
+		"dialog.ok":     "Confirm", // 显式定义的 key 优先
+		"dialog.cancel": "Cancel",
+		"dialog.title":  "Dialog",
+		"wizard.help":   "Help",
+		"wizard.cancel": "Abort", // 序列中靠前的映射优先
+		"wizard.ok":     "OK",
+	}
+	for k, v := range want {
+		if got := f.Messages[k]; got != v {
+			t.Errorf("Messages[%q] = %q, want %q", k, got, v)
+		}
+	}
+	for k := range f.Messages {
+		if strings.Contains(k, "<<") {
+			t.Errorf("merge key should not be kept literally: %q", k)
+		}
+	}
+	if src := f.Sources["dialog.ok"]; src.Line != 12 {
+		t.Errorf("Sources[dialog.ok] = %v, want line 12", src)
+	}
+
+	bad := "language: en\nmessages:\n  a:\n    <<: plain\n"
+	if _, err := ParseLocaleFile("en.yaml", strings.NewReader(bad)); err == nil {
+		t.Fatal("expected error for a scalar merge value")
+	}
+}
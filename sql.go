@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SQLSource 通过 database/sql 从数据表读取翻译。
+// 查询由调用方完整给出（表名、列名的引用方式与占位符因数据库而异），
+// 结果必须依次包含 lang、key、text、updated_at 四列，列名本身不限。
+//
+// 第一次加载执行 Query 读取全部翻译，之后的刷新执行 IncrementalQuery，
+// 只读取 updated_at 不早于上次最大值的行。增量刷新无法感知被删除的行，
+// 需要删除翻译时请使用 LoadSQL 重新全量加载（或 Bundle.RemoveMessages）。
+type SQLSource struct {
+	DB *sql.DB
+	// Query 全量查询
+	Query string
+	// IncrementalQuery 增量查询，唯一的参数为上次读到的最大 updated_at；
+	// 为空时 RefreshSQL 每次都执行全量查询
+	IncrementalQuery string
+
+	mu    sync.Mutex // 保证同一时刻只有一次加载，since 按顺序推进
+	since time.Time
+}
+
+// NewSQLSource 用全量查询 query 与增量查询 incrementalQuery（可以为空）创建 SQLSource。
+// `key` 在 MySQL 中是保留字，需要加引号：
+//
+//	// MySQL
+//	i18n.NewSQLSource(db,
+//		"SELECT lang, `key`, text, updated_at FROM i18n_messages",
+//		"SELECT lang, `key`, text, updated_at FROM i18n_messages WHERE updated_at >= ?")
+//
+//	// PostgreSQL
+//	i18n.NewSQLSource(db,
+//		`SELECT lang, "key", text, updated_at FROM i18n_messages`,
+//		`SELECT lang, "key", text, updated_at FROM i18n_messages WHERE updated_at >= $1`)
+func NewSQLSource(db *sql.DB, query, incrementalQuery string) *SQLSource {
+	return &SQLSource{
+		DB:               db,
+		Query:            query,
+		IncrementalQuery: incrementalQuery,
+	}
+}
+
+// Since 返回目前读到的最大 updated_at，还没有加载过时为零值
+func (s *SQLSource) Since() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.since
+}
+
+// LoadSQL 全量读取 src 中的翻译并合并进 Bundle，语义与 RegisterMessages 相同。
+// 数据库中的翻译没有签名，严格模式（Config.RequireSignature）下 LoadSQL 与 RefreshSQL 总是返回 ErrUnsigned
+func (b *Bundle) LoadSQL(ctx context.Context, src *SQLSource) error {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	return src.loadLocked(ctx, b, false)
+}
+
+// RefreshSQL 增量读取上次加载之后有更新的翻译并合并进 Bundle；
+// 还没有全量加载过或者没有设置 IncrementalQuery 时等同于 LoadSQL。
+//
+// 增量查询使用 updated_at >= 上次最大值，边界上的行会被重复读取，
+// 以免遗漏与上次最大值同一时刻写入的行；重复合并不影响结果。
+func (b *Bundle) RefreshSQL(ctx context.Context, src *SQLSource) error {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	return src.loadLocked(ctx, b, !src.since.IsZero() && src.IncrementalQuery != "")
+}
+
+func (s *SQLSource) loadLocked(ctx context.Context, b *Bundle, incremental bool) error {
+	if b.config.RequireSignature {
+		return fmt.Errorf("i18n: load messages from SQL: %w", ErrUnsigned)
+	}
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if incremental {
+		rows, err = s.DB.QueryContext(ctx, s.IncrementalQuery, s.since)
+	} else {
+		rows, err = s.DB.QueryContext(ctx, s.Query)
+	}
+	if err != nil {
+		return fmt.Errorf("i18n: query messages: %w", err)
+	}
+	defer rows.Close()
+
+	// 先读完所有行再注册，查询中途失败时 Bundle 保持不变
+	langs := make(map[string]map[string]string)
+	since := s.since
+	for rows.Next() {
+		var (
+			lang, key, text string
+			updatedAt       time.Time
+		)
+		if err := rows.Scan(&lang, &key, &text, &updatedAt); err != nil {
+			return fmt.Errorf("i18n: scan message row: %w", err)
+		}
+		if langs[lang] == nil {
+			langs[lang] = make(map[string]string)
+		}
+		langs[lang][key] = text
+		if updatedAt.After(since) {
+			since = updatedAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("i18n: read messages: %w", err)
+	}
+
+	for lang, msgs := range langs {
+		if err := b.RegisterMessages(lang, msgs); err != nil {
+			return err
+		}
+	}
+	s.since = since
+	return nil
+}
@@ -0,0 +1,182 @@
+package i18n
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDriver 是只支持 SELECT 的内存 driver.Driver：
+// 不带参数时返回全部行，带一个参数时返回 updated_at >= 参数的行
+type fakeDriver struct {
+	mu      sync.Mutex
+	rows    [][]driver.Value // lang, key, text, updated_at
+	fail    error
+	queries []string
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+// reset 清空数据与记录的查询，并设置之后查询返回的错误
+func (d *fakeDriver) reset(fail error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rows, d.queries, d.fail = nil, nil, fail
+}
+
+// setFail 设置之后查询返回的错误，数据保持不变
+func (d *fakeDriver) setFail(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fail = err
+}
+
+func (d *fakeDriver) lastQuery() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.queries) == 0 {
+		return ""
+	}
+	return d.queries[len(d.queries)-1]
+}
+
+func (d *fakeDriver) set(lang, key, text string, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, r := range d.rows {
+		if r[0] == lang && r[1] == key {
+			r[2], r[3] = text, at
+			return
+		}
+	}
+	d.rows = append(d.rows, []driver.Value{lang, key, text, at})
+}
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.queries = append(c.d.queries, query)
+	return &fakeStmt{d: c.d}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeStmt struct{ d *fakeDriver }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if s.d.fail != nil {
+		return nil, s.d.fail
+	}
+	var out [][]driver.Value
+	for _, r := range s.d.rows {
+		if len(args) == 1 && r[3].(time.Time).Before(args[0].(time.Time)) {
+			continue
+		}
+		out = append(out, append([]driver.Value(nil), r...))
+	}
+	return &fakeRows{rows: out}, nil
+}
+
+type fakeRows struct{ rows [][]driver.Value }
+
+func (r *fakeRows) Columns() []string { return []string{"lang", "key", "text", "updated_at"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var fakeSQL = &fakeDriver{}
+
+func init() {
+	sql.Register("i18n-fake", fakeSQL)
+}
+
+func TestBundle_LoadSQL(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeSQL.reset(nil)
+	fakeSQL.set("en", "hello", "Hello {name}", t0)
+	fakeSQL.set("en", "bye", "Bye", t0)
+	fakeSQL.set("zh-CN", "hello", "你好 {name}", t0.Add(time.Minute))
+
+	db, err := sql.Open("i18n-fake", "")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	const (
+		query       = "SELECT lang, `key`, text, updated_at FROM i18n_messages"
+		incremental = query + " WHERE updated_at >= ?"
+	)
+	src := NewSQLSource(db, query, incremental)
+	bundle := New(Config{DefaultLang: "en"})
+	if err := bundle.LoadSQL(ctx, src); err != nil {
+		t.Fatalf("LoadSQL: %v", err)
+	}
+	if got := bundle.Locale("zh-CN").T("hello", map[string]any{"name": "Ann"}); got != "你好 Ann" {
+		t.Fatalf("T: %q", got)
+	}
+	if !src.Since().Equal(t0.Add(time.Minute)) {
+		t.Fatalf("Since = %v", src.Since())
+	}
+
+	// 增量刷新只合并更新过的行
+	fakeSQL.set("en", "bye", "Goodbye", t0.Add(time.Hour))
+	bundle.RegisterMessages("en", map[string]string{"hello": "Hi {name}"})
+	if err := bundle.RefreshSQL(ctx, src); err != nil {
+		t.Fatalf("RefreshSQL: %v", err)
+	}
+	if q := fakeSQL.lastQuery(); q != incremental {
+		t.Fatalf("RefreshSQL ran %q, want the incremental query", q)
+	}
+	loc := bundle.Locale("en")
+	if got := loc.T("bye", nil); got != "Goodbye" {
+		t.Fatalf("bye after refresh: %q", got)
+	}
+	if got := loc.T("hello", map[string]any{"name": "Ann"}); got != "Hi Ann" {
+		t.Fatalf("unchanged rows should not be reloaded: %q", got)
+	}
+
+	// 查询失败时保留已有翻译
+	fakeSQL.setFail(errors.New("connection refused"))
+	defer fakeSQL.setFail(nil)
+	if err := bundle.RefreshSQL(ctx, src); err == nil {
+		t.Fatal("expected query error")
+	}
+	if got := loc.T("bye", nil); got != "Goodbye" {
+		t.Fatalf("bye after failed refresh: %q", got)
+	}
+	fakeSQL.setFail(nil)
+
+	// 没有增量查询时每次刷新都是全量查询
+	full := NewSQLSource(db, query, "")
+	for i := 0; i < 2; i++ {
+		if err := bundle.RefreshSQL(ctx, full); err != nil {
+			t.Fatalf("RefreshSQL: %v", err)
+		}
+		if q := fakeSQL.lastQuery(); q != query {
+			t.Fatalf("RefreshSQL ran %q, want the full query", q)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// Store 是翻译数据的存储接口，Bundle 通过它读写翻译，默认实现为内存中的 MemoryStore。
+// 实现 Store 即可把翻译放到 SQL、KV 等其他存储中（见 Config.Store）。
+//
+// Store 必须可以并发使用：Bundle 不会在持有自身读写锁时调用 Store，
+// 写操作（Set/Delete）由 Bundle 串行执行，但读操作（Get/Languages/Keys）可能与写操作并发执行。
+// 任何方法返回的错误都会原样（包装后）返回给调用方。
+type Store interface {
+	// Get 返回某个语言下 key 对应的翻译，不存在时返回 false 与 nil 错误
+	Get(lang, key string) (string, bool, error)
+	// Set 写入一条翻译
+	Set(lang, key, text string) error
+	// Delete 删除一条翻译，不存在时忽略
+	Delete(lang, key string) error
+	// Languages 返回所有有翻译的语言
+	Languages() ([]string, error)
+	// Keys 返回某个语言下的所有 key
+	Keys(lang string) ([]string, error)
+}
+
+// ErrCustomStore 对使用自定义 Store 的 Bundle 调用 Watch/WatchFS/WatchRemote 时返回：
+// 热更新需要整体替换翻译，只支持默认的内存存储
+var ErrCustomStore = errors.New("i18n: hot reload is not supported with a custom Store")
+
+// MessageStore lang -> key -> message
+// 是不加锁的内存 Store，适合单个 goroutine 中构造数据；交给 Bundle 使用时请用 MemoryStore
+type MessageStore map[string]map[string]string
+
+var _ Store = MessageStore(nil)
+
+// Get 实现 Store
+func (s MessageStore) Get(lang, key string) (string, bool, error) {
+	text, ok := s[lang][key]
+	return text, ok, nil
+}
+
+// Set 实现 Store
+func (s MessageStore) Set(lang, key, text string) error {
+	msgs, ok := s[lang]
+	if !ok {
+		msgs = make(map[string]string)
+		s[lang] = msgs
+	}
+	msgs[key] = text
+	return nil
+}
+
+// Delete 实现 Store，语言下没有 key 时一并删除该语言
+func (s MessageStore) Delete(lang, key string) error {
+	msgs, ok := s[lang]
+	if !ok {
+		return nil
+	}
+	delete(msgs, key)
+	if len(msgs) == 0 {
+		delete(s, lang)
+	}
+	return nil
+}
+
+// Languages 实现 Store，结果按字典序排列
+func (s MessageStore) Languages() ([]string, error) {
+	langs := make([]string, 0, len(s))
+	for lang := range s {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs, nil
+}
+
+// Keys 实现 Store，结果按字典序排列
+func (s MessageStore) Keys(lang string) ([]string, error) {
+	keys := make([]string, 0, len(s[lang]))
+	for k := range s[lang] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// MemoryStore 是并发安全的内存 Store，Config.Store 为 nil 时 Bundle 使用它
+type MemoryStore struct {
+	mu   sync.RWMutex
+	msgs MessageStore
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore 创建一个空的 MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{msgs: make(MessageStore)}
+}
+
+// Get 实现 Store
+func (s *MemoryStore) Get(lang, key string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.msgs.Get(lang, key)
+}
+
+// Set 实现 Store
+func (s *MemoryStore) Set(lang, key, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.msgs.Set(lang, key, text)
+}
+
+// Delete 实现 Store
+func (s *MemoryStore) Delete(lang, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.msgs.Delete(lang, key)
+}
+
+// Languages 实现 Store，结果按字典序排列
+func (s *MemoryStore) Languages() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.msgs.Languages()
+}
+
+// Keys 实现 Store，结果按字典序排列
+func (s *MemoryStore) Keys(lang string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.msgs.Keys(lang)
+}
+
+// replace 在一次加锁内用 msgs 整体替换某个语言的翻译，读操作不会看到替换了一半的状态
+func (s *MemoryStore) replace(lang string, msgs map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(msgs) == 0 {
+		delete(s.msgs, lang)
+		return
+	}
+	cp := make(map[string]string, len(msgs))
+	for k, v := range msgs {
+		cp[k] = v
+	}
+	s.msgs[lang] = cp
+}
+
+// Store 返回 Bundle 当前使用的 Store
+func (b *Bundle) Store() Store {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.store
+}
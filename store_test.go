@@ -0,0 +1,183 @@
+package i18n
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// fakeStore 是记录调用次数的 Store，用来验证 Bundle 只通过接口访问翻译；
+// err 不为 nil 时所有调用都返回该错误，held 不为 nil 时每次调用都检查 Bundle 的锁没有被持有
+type fakeStore struct {
+	mu   sync.Mutex
+	data MessageStore
+	gets int
+	sets int
+	err  error
+	held func() bool
+}
+
+func (s *fakeStore) call() error {
+	if s.held != nil && s.held() {
+		panic("store called while holding the bundle lock")
+	}
+	return s.err
+}
+
+func (s *fakeStore) Get(lang, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call(); err != nil {
+		return "", false, err
+	}
+	s.gets++
+	return s.data.Get(lang, key)
+}
+
+func (s *fakeStore) Set(lang, key, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call(); err != nil {
+		return err
+	}
+	s.sets++
+	return s.data.Set(lang, key, text)
+}
+
+func (s *fakeStore) Delete(lang, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call(); err != nil {
+		return err
+	}
+	return s.data.Delete(lang, key)
+}
+
+func (s *fakeStore) Languages() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call(); err != nil {
+		return nil, err
+	}
+	return s.data.Languages()
+}
+
+func (s *fakeStore) Keys(lang string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.call(); err != nil {
+		return nil, err
+	}
+	return s.data.Keys(lang)
+}
+
+func TestBundle_CustomStore(t *testing.T) {
+	store := &fakeStore{data: make(MessageStore)}
+	bundle := New(Config{DefaultLang: "en", Store: store})
+	store.held = func() bool {
+		if !bundle.mu.TryLock() {
+			return true
+		}
+		bundle.mu.Unlock()
+		return false
+	}
+	if bundle.Store() != Store(store) {
+		t.Fatal("Store should return the configured store")
+	}
+
+	fsys := fstest.MapFS{
+		"en.yaml": {Data: []byte("language: en\nmessages:\n  hello: Hello {name}\n  bye: Bye\n")},
+	}
+	if err := bundle.LoadFS(fsys, "."); err != nil {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	bundle.RegisterMessages("fr", map[string]string{"hello": "Bonjour {name}"})
+	if store.sets != 3 {
+		t.Fatalf("sets = %d, want 3", store.sets)
+	}
+
+	if got := bundle.Locale("fr").T("hello", map[string]any{"name": "Ann"}); got != "Bonjour Ann" {
+		t.Fatalf("T: %q", got)
+	}
+	if got := bundle.Locale("fr").T("bye", nil); got != "Bye" {
+		t.Fatalf("fallback T: %q", got)
+	}
+	if store.gets == 0 {
+		t.Fatal("Locale.T should read through the store")
+	}
+
+	bundle.ReplaceMessages("en", map[string]string{"hello": "Hi"})
+	if got, _ := store.Keys("en"); !reflect.DeepEqual(got, []string{"hello"}) {
+		t.Fatalf("keys after replace: %v", got)
+	}
+	bundle.UnloadLanguage("fr")
+	if got, _ := store.Languages(); !reflect.DeepEqual(got, []string{"en"}) {
+		t.Fatalf("languages after unload: %v", got)
+	}
+
+	// Clone 把翻译复制到独立的内存存储
+	clone := mustClone(t, bundle)
+	clone.RegisterMessages("en", map[string]string{"bye": "Bye"})
+	if _, ok, _ := store.Get("en", "bye"); ok {
+		t.Fatal("clone should not write into the original store")
+	}
+
+	if _, err := bundle.WatchFS(fsys, ".", time.Hour); !errors.Is(err, ErrCustomStore) {
+		t.Fatalf("WatchFS: expected ErrCustomStore, got %v", err)
+	}
+	if _, err := bundle.WatchRemote("http://127.0.0.1/{lang}.yaml", RemoteOptions{Languages: []string{"en"}}); !errors.Is(err, ErrCustomStore) {
+		t.Fatalf("WatchRemote: expected ErrCustomStore, got %v", err)
+	}
+}
+
+func TestBundle_StoreErrors(t *testing.T) {
+	errDown := errors.New("store down")
+	store := &fakeStore{data: make(MessageStore)}
+	bundle := New(Config{Store: store})
+	if err := bundle.RegisterMessages("en", map[string]string{"hello": "Hello"}); err != nil {
+		t.Fatalf("RegisterMessages: %v", err)
+	}
+
+	store.err = errDown
+	if err := bundle.RegisterMessages("en", map[string]string{"bye": "Bye"}); !errors.Is(err, errDown) {
+		t.Fatalf("RegisterMessages: %v", err)
+	}
+	fsys := fstest.MapFS{"en.yaml": {Data: []byte("language: en\nmessages:\n  bye: Bye\n")}}
+	if err := bundle.LoadFS(fsys, "."); !errors.Is(err, errDown) {
+		t.Fatalf("LoadFS: %v", err)
+	}
+	if text, err := bundle.Locale("en").Translate("hello", nil); !errors.Is(err, errDown) || text != "hello" {
+		t.Fatalf("Translate: %q, %v", text, err)
+	}
+	if got := bundle.Locale("en").T("hello", nil); got != "hello" {
+		t.Fatalf("T should fall back to the key, got %q", got)
+	}
+	if _, err := bundle.Clone(); !errors.Is(err, errDown) {
+		t.Fatalf("Clone: %v", err)
+	}
+	if err := bundle.UnloadLanguage("en"); !errors.Is(err, errDown) {
+		t.Fatalf("UnloadLanguage: %v", err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	s.Set("en", "b", "B")
+	s.Set("en", "a", "A")
+	s.Set("de", "a", "A")
+
+	if text, ok, _ := s.Get("en", "a"); !ok || text != "A" {
+		t.Fatalf("Get: %q, %v", text, ok)
+	}
+	if got, _ := s.Keys("en"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("Keys: %v", got)
+	}
+	s.Delete("de", "a")
+	s.Delete("fr", "a")
+	if got, _ := s.Languages(); !reflect.DeepEqual(got, []string{"en"}) {
+		t.Fatalf("Languages: %v", got)
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"log"
-	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -54,12 +52,14 @@ type PlaceholderNode struct {
 
 func (p *PlaceholderNode) Eval(args map[string]any) (string, error) {
 	// Resolve base value
-	value, ok := getValueByPath(args, p.Path)
+	value, ok, err := getValueByPath(args, p.Path)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Path, err)
+	}
 	if !ok {
 		return "", fmt.Errorf("value not found: %s", p.Path)
 	}
 
-	var err error
 	// Apply chained formatters
 	for _, f := range p.Formatters {
 		value, err = applyRegisteredFormatter(value, f.Name, f.Arg)
@@ -80,7 +80,7 @@ func (p *PlaceholderNode) Eval(args map[string]any) (string, error) {
 		return RenderTemplate(p.Cond.FalseExpr, args)
 	}
 
-	return fmt.Sprint(value), nil
+	return stringify(value)
 }
 
 // TemplateAST is a whole parsed template.
@@ -132,6 +132,15 @@ func RenderTemplate(tpl string, args map[string]any) (string, error) {
 	return ast.Eval(args)
 }
 
+// RegisterTemplate seeds the AST cache with a pre-parsed template,
+// so RenderTemplate never parses tpl at runtime.
+// It is used by the code generated by cmd/i18ngen.
+func RegisterTemplate(tpl string, ast TemplateAST) {
+	cacheMutex.Lock()
+	defer cacheMutex.Unlock()
+	astCache[tpl] = ast
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // TEMPLATE PARSER
 ///////////////////////////////////////////////////////////////////////////////
@@ -300,37 +309,32 @@ func parseConditional(expr string) (*Conditional, error) {
 // REMAINS: VALUE RESOLUTION / NUMBER / DATE (reuse your existing logic)
 ///////////////////////////////////////////////////////////////////////////////
 
-func getValueByPath(args map[string]any, path string) (any, bool) {
+func getValueByPath(args map[string]any, path string) (any, bool, error) {
 	segs := strings.Split(path, ".")
 	var current any = args
+	// Tf / KV 单独传入的结构体，路径从结构体开始解析
+	if root, ok := args[rootArgKey]; ok {
+		current = root
+	}
 
 	for _, seg := range segs {
-		log.Println(seg)
 		switch c := current.(type) {
 		case map[string]any:
 			v, ok := c[seg]
 			if !ok {
-				return nil, false
+				return nil, false, nil
 			}
 			current = v
 		default:
-			r := reflect.ValueOf(c)
-			if r.Kind() == reflect.Ptr {
-				r = r.Elem()
-			}
-			if r.Kind() != reflect.Struct {
-				return nil, false
+			// structs, string-keyed maps and getter methods, see resolveMember
+			v, ok, err := resolveMember(c, seg)
+			if err != nil || !ok {
+				return nil, false, err
 			}
-			f := r.FieldByNameFunc(func(name string) bool {
-				return strings.EqualFold(name, seg)
-			})
-			if !f.IsValid() {
-				return nil, false
-			}
-			current = f.Interface()
+			current = v
 		}
 	}
-	return current, true
+	return current, true, nil
 }
 
 func formatDate(v any, layout string) (string, error) {
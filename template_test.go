@@ -94,3 +94,16 @@ func TestRenderTemplate(t *testing.T) {
 	})
 
 }
+
+func TestRegisterTemplate(t *testing.T) {
+	tpl := "precompiled {name}"
+	RegisterTemplate(tpl, TemplateAST{&TextNode{Text: "pre:"}, &PlaceholderNode{Path: "name"}})
+	got, err := RenderTemplate(tpl, map[string]any{"name": "Ann"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 使用注册的 AST 而不是重新解析
+	if got != "pre:Ann" {
+		t.Fatalf("RenderTemplate = %q", got)
+	}
+}
@@ -0,0 +1,194 @@
+package i18n
+
+import (
+	"crypto/sha256"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// fileStamp 记录一个翻译文件的状态，用于判断文件是否发生变化
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	hash    [sha256.Size]byte
+}
+
+// Watcher 以轮询的方式监听翻译目录（只依赖标准库）：
+// 每隔 interval 比较文件的 mtime/size，有变化时再比较内容 hash，
+// 确认内容变化后重新构建一份新的 MemoryStore 并原子替换到 Bundle 中。
+// 重新加载失败时保留上一次成功加载的翻译。
+//
+// 注意：替换是整体的，通过 RegisterMessages 等方式额外注册到 Bundle 的翻译会在重新加载后丢失；
+// 使用自定义 Store 的 Bundle 无法整体替换，Watch/WatchFS 返回 ErrCustomStore。
+type Watcher struct {
+	bundle   *Bundle
+	fsys     fs.FS
+	root     string
+	interval time.Duration
+
+	mu       sync.Mutex
+	stamps   map[string]fileStamp
+	onReload []func(err error)
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Watch 监听 dir 目录，等同于 WatchFS(os.DirFS(dir), ".", interval)
+func (b *Bundle) Watch(dir string, interval time.Duration) (*Watcher, error) {
+	return b.WatchFS(os.DirFS(dir), ".", interval)
+}
+
+// WatchFS 先同步加载一次 fsys 中 root 目录下的翻译文件并替换 Bundle 的翻译，
+// 成功后启动后台轮询。使用完毕后需要调用 Close 停止轮询。
+func (b *Bundle) WatchFS(fsys fs.FS, root string, interval time.Duration) (*Watcher, error) {
+	if b.config.Store != nil {
+		return nil, ErrCustomStore
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+	w := &Watcher{
+		bundle:   b,
+		fsys:     fsys,
+		root:     root,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	stamps, _, err := w.scan()
+	if err != nil {
+		return nil, err
+	}
+	if err := w.reload(); err != nil {
+		return nil, err
+	}
+	w.stamps = stamps
+	go w.loop()
+	return w, nil
+}
+
+// OnReload 注册重新加载后的回调，err 为 nil 表示加载成功，
+// 否则表示加载失败，Bundle 仍在使用上一次成功加载的翻译
+func (w *Watcher) OnReload(fn func(err error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onReload = append(w.onReload, fn)
+}
+
+// Reload 立即重新加载一次，不论文件是否变化
+func (w *Watcher) Reload() error {
+	err := w.reload()
+	w.notify(err)
+	return err
+}
+
+// Close 停止轮询
+func (w *Watcher) Close() error {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+	return nil
+}
+
+func (w *Watcher) loop() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll 检查一次文件变化，有变化时重新加载
+func (w *Watcher) poll() {
+	stamps, changed, err := w.scan()
+	if err != nil {
+		w.notify(err)
+		return
+	}
+	if !changed {
+		return
+	}
+	// 不论加载成功与否都更新快照，避免对同一份坏文件反复报错；
+	// 译者修复文件后会再次触发加载
+	w.mu.Lock()
+	w.stamps = stamps
+	w.mu.Unlock()
+	w.notify(w.reload())
+}
+
+// scan 生成当前目录的文件快照，并与上一次的快照比较
+// 只有 mtime/size 发生变化的文件才会重新计算 hash
+func (w *Watcher) scan() (map[string]fileStamp, bool, error) {
+	w.mu.Lock()
+	prev := w.stamps
+	w.mu.Unlock()
+
+	stamps := make(map[string]fileStamp, len(prev))
+	changed := false
+	err := fs.WalkDir(w.fsys, w.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// 签名文件单独更新时也需要重新加载
+		if d.IsDir() || !(isLocaleFile(p) || isSignatureFile(p)) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		st := fileStamp{modTime: info.ModTime(), size: info.Size()}
+		old, ok := prev[p]
+		if ok && old.modTime.Equal(st.modTime) && old.size == st.size {
+			stamps[p] = old
+			return nil
+		}
+		data, err := fs.ReadFile(w.fsys, p)
+		if err != nil {
+			return err
+		}
+		st.hash = sha256.Sum256(data)
+		if !ok || old.hash != st.hash {
+			changed = true
+		}
+		stamps[p] = st
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(stamps) != len(prev) {
+		changed = true
+	}
+	return stamps, changed, nil
+}
+
+// reload 把目录完整加载到一个新的 Bundle 中，成功后再整体替换
+func (w *Watcher) reload() error {
+	fresh := New(w.bundle.config)
+	if err := fresh.LoadFS(w.fsys, w.root); err != nil {
+		return err
+	}
+	w.bundle.swap(fresh)
+	return nil
+}
+
+func (w *Watcher) notify(err error) {
+	w.mu.Lock()
+	fns := append([]func(error){}, w.onReload...)
+	w.mu.Unlock()
+	for _, fn := range fns {
+		fn(err)
+	}
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeLocale(t *testing.T, path, content string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBundle_Watch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "en.yaml")
+	now := time.Now()
+	writeLocale(t, file, "language: en\nmessages:\n  hello: v1\n", now)
+
+	bundle := New(Config{})
+	bundle.RegisterMessages("en", map[string]string{"stale": "old"})
+	w, err := bundle.Watch(dir, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer w.Close()
+
+	reloaded := make(chan error, 8)
+	w.OnReload(func(err error) { reloaded <- err })
+
+	loc := bundle.Locale("en")
+	if got := loc.T("hello", nil); got != "v1" {
+		t.Fatalf("T: %q", got)
+	}
+	if got := loc.T("stale", nil); got != "stale" {
+		t.Fatalf("stale key should be dropped by the initial swap, got %q", got)
+	}
+
+	waitReload := func() error {
+		t.Helper()
+		select {
+		case err := <-reloaded:
+			return err
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for reload")
+			return nil
+		}
+	}
+
+	t.Run("Watcher_Reload_Success", func(t *testing.T) {
+		writeLocale(t, file, "language: en\nmessages:\n  hello: v2\n", now.Add(time.Second))
+		if err := waitReload(); err != nil {
+			t.Fatalf("reload: %v", err)
+		}
+		if got := loc.T("hello", nil); got != "v2" {
+			t.Fatalf("T: %q", got)
+		}
+	})
+
+	t.Run("Watcher_Reload_KeepLastGood", func(t *testing.T) {
+		writeLocale(t, file, "language: en\nmessages: [broken\n", now.Add(2*time.Second))
+		if err := waitReload(); err == nil {
+			t.Fatal("expected reload error")
+		}
+		if got := loc.T("hello", nil); got != "v2" {
+			t.Fatalf("T: %q", got)
+		}
+	})
+}